@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+)
+
+var outFlag string
+
+func init() {
+	flag.StringVar(&outFlag, "out", "pkg/event/schema", "Output directory for the generated JSON Schema documents")
+	flag.Parse()
+}
+
+func main() {
+	if err := os.MkdirAll(outFlag, 0o755); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, txType := range event.TxTypes() {
+		schema, err := event.JSONSchema(txType)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(outFlag, txType+".json"), append(schema, '\n'), 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -18,9 +18,13 @@ import (
 	"github.com/grassrootseconomics/eth-tracker/internal/backfill"
 	"github.com/grassrootseconomics/eth-tracker/internal/cache"
 	"github.com/grassrootseconomics/eth-tracker/internal/chain"
+	"github.com/grassrootseconomics/eth-tracker/internal/enricher"
+	"github.com/grassrootseconomics/eth-tracker/internal/outbox"
 	"github.com/grassrootseconomics/eth-tracker/internal/pool"
 	"github.com/grassrootseconomics/eth-tracker/internal/processor"
 	"github.com/grassrootseconomics/eth-tracker/internal/pub"
+	"github.com/grassrootseconomics/eth-tracker/internal/revert"
+	"github.com/grassrootseconomics/eth-tracker/internal/sequencer"
 	"github.com/grassrootseconomics/eth-tracker/internal/stats"
 	"github.com/grassrootseconomics/eth-tracker/internal/syncer"
 	"github.com/grassrootseconomics/eth-tracker/internal/util"
@@ -75,7 +79,7 @@ func main() {
 	cacheOpts := cache.CacheOpts{
 		Chain:      chain,
 		Registries: ko.MustStrings("bootstrap.ge_registry"),
-		Watchlist:  ko.Strings("bootstrap.watchlist"),
+		Watchlist:  append(ko.Strings("bootstrap.watchlist"), ko.Strings("aa.entry_points")...),
 		Blacklist:  ko.Strings("bootstrap.blacklist"),
 		CacheType:  ko.MustString("core.cache_type"),
 		Logg:       lo,
@@ -90,33 +94,107 @@ func main() {
 	}
 	lo.Debug("loaded and boostrapped cache")
 
-	jetStreamPub, err := pub.NewJetStreamPub(pub.JetStreamOpts{
-		Endpoint:        ko.MustString("jetstream.endpoint"),
-		PersistDuration: time.Duration(ko.MustInt("jetstream.persist_duration_hrs")) * time.Hour,
-		Logg:            lo,
-	})
+	publisher, err := bootstrapPub()
 	if err != nil {
-		lo.Error("could not initialize jetstream pub", "error", err)
+		lo.Error("could not initialize publisher", "error", err)
 		os.Exit(1)
 	}
-	lo.Debug("loaded jetstream publisher")
 
-	router := bootstrapEventRouter(cache, jetStreamPub.Send)
+	pubCB := publisher.Send
+
+	var relay *outbox.Relay
+	if ko.Bool("outbox.enable") {
+		relay = outbox.NewRelay(outbox.RelayOpts{
+			Store:         db,
+			Pub:           publisher,
+			BatchSize:     ko.Int("outbox.batch_size"),
+			RetryInterval: time.Duration(ko.Int("outbox.retry_interval_secs")) * time.Second,
+			Logg:          lo,
+		})
+		pubCB = outbox.Stage
+		lo.Debug("loaded outbox relay")
+	}
+
+	var blockSequencer *sequencer.Sequencer
+	if ko.Bool("ordering.enable") {
+		if relay != nil {
+			lo.Error("ordered publishing cannot be combined with the outbox")
+			os.Exit(1)
+		}
+
+		blockSequencer = sequencer.New(sequencer.SequencerOpts{
+			Pub:           publisher,
+			MaxBlocks:     ko.Int("ordering.max_blocks"),
+			RetryInterval: time.Duration(ko.Int("ordering.retry_interval_secs")) * time.Second,
+			Logg:          lo,
+		})
+		pubCB = sequencer.Collect
+		blockSequencer.Start()
+		lo.Debug("started block sequencer")
+	}
+
+	if ko.Bool("enrichment.enable") {
+		tokenEnricher := enricher.New(enricher.EnricherOpts{
+			Chain:       chain,
+			CacheSize:   ko.Int("enrichment.cache_size"),
+			MaxEventAge: time.Duration(ko.Int("enrichment.max_event_age_secs")) * time.Second,
+			Logg:        lo,
+		})
+		pubCB = tokenEnricher.Callback(pubCB)
+		lo.Debug("loaded token metadata enricher")
+	}
+
+	routerCB := pubCB
+	if ko.Bool("block_marker.enable") {
+		routerCB = processor.CountEvents(pubCB)
+	}
+
+	router := bootstrapEventRouter(cache, chain, routerCB)
 	lo.Debug("bootstrapped event router")
 
-	blockProcessor := processor.NewProcessor(processor.ProcessorOpts{
-		Cache:  cache,
-		Chain:  chain,
-		DB:     db,
-		Router: router,
-		Logg:   lo,
-	})
+	processorOpts := processor.ProcessorOpts{
+		Cache:       cache,
+		Chain:       chain,
+		DB:          db,
+		Router:      router,
+		Outbox:      relay,
+		Sequencer:   blockSequencer,
+		EntryPoints: ko.Strings("aa.entry_points"),
+		Logg:        lo,
+	}
+	if ko.Bool("block_marker.enable") {
+		processorOpts.BlockMarker = pubCB
+	}
+	if flusher, ok := publisher.(pub.BlockFlusher); ok && relay == nil && blockSequencer == nil {
+		processorOpts.Flush = flusher.FlushBlock
+	}
+	if ko.Bool("revert.enable") {
+		revertDecoder, err := revert.NewDecoder(revert.DecoderOpts{
+			CustomErrors: ko.Strings("revert.custom_errors"),
+		})
+		if err != nil {
+			lo.Error("could not initialize revert decoder", "error", err)
+			os.Exit(1)
+		}
+		processorOpts.RevertDecoder = revertDecoder
+	}
+	blockProcessor := processor.NewProcessor(processorOpts)
 	lo.Debug("bootstrapped processor")
 
 	poolOpts := pool.PoolOpts{
 		Logg:        lo,
 		WorkerCount: ko.Int("core.pool_size"),
 		Processor:   blockProcessor,
+		LaneShares: map[pool.Lane]float64{
+			pool.LaneRealtime:  ko.Float64("core.realtime_share"),
+			pool.LaneReprocess: ko.Float64("core.reprocess_share"),
+			pool.LaneBackfill:  ko.Float64("core.backfill_share"),
+		},
+		MaxAttempts:    ko.Int("core.max_attempts"),
+		InitialBackoff: time.Duration(ko.Int("core.initial_backoff_secs")) * time.Second,
+		MaxBackoff:     time.Duration(ko.Int("core.max_backoff_secs")) * time.Second,
+		DeadLetters:    db,
+		Sequencer:      blockSequencer,
 	}
 	if ko.Int("core.pool_size") <= 0 {
 		poolOpts.WorkerCount = runtime.NumCPU() * 3
@@ -155,12 +233,19 @@ func main() {
 	lo.Debug("bootstrapped backfiller")
 
 	apiServer := &http.Server{
-		Addr:    ko.MustString("api.address"),
-		Handler: api.New(),
+		Addr: ko.MustString("api.address"),
+		Handler: api.New(api.APIOpts{
+			Pool: workerPool,
+		}),
 	}
 	lo.Debug("bootstrapped API server")
 	lo.Debug("starting routines")
 
+	if relay != nil {
+		relay.Start()
+		lo.Debug("started outbox relay")
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -199,7 +284,13 @@ func main() {
 		chainSyncer.Stop()
 		backfill.Stop()
 		workerPool.Stop()
-		jetStreamPub.Close()
+		if blockSequencer != nil {
+			blockSequencer.Stop()
+		}
+		if relay != nil {
+			relay.Stop()
+		}
+		publisher.Close()
 		db.Cleanup()
 		db.Close()
 		apiServer.Shutdown(shutdownCtx)
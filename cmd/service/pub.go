@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/internal/pub"
+)
+
+// bootstrapPub initializes every publisher enabled in the config, several publishers are combined into a fan-out publisher
+func bootstrapPub() (pub.Pub, error) {
+	var enabled []pub.FanoutSink
+
+	if ko.Bool("jetstream.enable") {
+		jetStreamPub, err := pub.NewJetStreamPub(pub.JetStreamOpts{
+			Endpoint:        ko.MustString("jetstream.endpoint"),
+			PersistDuration: time.Duration(ko.MustInt("jetstream.persist_duration_hrs")) * time.Hour,
+			StreamName:      ko.String("jetstream.stream_name"),
+			SubjectTemplate: ko.String("jetstream.subject_template"),
+			Replicas:        ko.Int("jetstream.replicas"),
+			Storage:         ko.String("jetstream.storage"),
+			MaxBytes:        ko.Int64("jetstream.max_bytes"),
+			DuplicateWindow: time.Duration(ko.Int("jetstream.duplicate_window_mins")) * time.Minute,
+			ContentType:     ko.String("jetstream.content_type"),
+			CloudEventsMode: ko.String("jetstream.cloudevents_mode"),
+			AsyncMaxPending: ko.Int("jetstream.async_max_pending"),
+			AsyncAckTimeout: time.Duration(ko.Int("jetstream.async_ack_timeout_secs")) * time.Second,
+			ChainID:         ko.MustInt64("chain.chainid"),
+			Nats: pub.NatsOpts{
+				CredsFile:           ko.String("jetstream.creds_file"),
+				NKeySeedFile:        ko.String("jetstream.nkey_seed_file"),
+				User:                ko.String("jetstream.user"),
+				Password:            ko.String("jetstream.password"),
+				Token:               ko.String("jetstream.token"),
+				TLSCAFile:           ko.String("jetstream.tls_ca_file"),
+				TLSCertFile:         ko.String("jetstream.tls_cert_file"),
+				TLSKeyFile:          ko.String("jetstream.tls_key_file"),
+				MaxReconnects:       ko.Int("jetstream.max_reconnects"),
+				ReconnectWait:       time.Duration(ko.Int("jetstream.reconnect_wait_secs")) * time.Second,
+				PingInterval:        time.Duration(ko.Int("jetstream.ping_interval_secs")) * time.Second,
+				MaxPingsOutstanding: ko.Int("jetstream.max_pings_outstanding"),
+			},
+			Logg: lo,
+		})
+		if err != nil {
+			closeSinks(enabled)
+			return nil, err
+		}
+		enabled = append(enabled, fanoutSink("jetstream", jetStreamPub))
+		lo.Debug("loaded jetstream publisher")
+	}
+
+	if ko.Bool("kafka.enable") {
+		kafkaPub, err := pub.NewKafkaPub(pub.KafkaOpts{
+			Brokers:     ko.MustStrings("kafka.brokers"),
+			TopicPrefix: ko.String("kafka.topic_prefix"),
+			Topics:      ko.StringMap("kafka.topics"),
+			ContentType: ko.String("kafka.content_type"),
+			Logg:        lo,
+		})
+		if err != nil {
+			closeSinks(enabled)
+			return nil, err
+		}
+		enabled = append(enabled, fanoutSink("kafka", kafkaPub))
+		lo.Debug("loaded kafka publisher")
+	}
+
+	if ko.Bool("webhook.enable") {
+		var subscribers []pub.WebhookSubscriber
+		for _, s := range ko.Slices("webhook.subscribers") {
+			subscribers = append(subscribers, pub.WebhookSubscriber{
+				Name:       s.MustString("name"),
+				URL:        s.MustString("url"),
+				Secret:     s.MustString("secret"),
+				Contracts:  s.Strings("contracts"),
+				EventTypes: s.Strings("event_types"),
+			})
+		}
+
+		webhookPub, err := pub.NewWebhookPub(pub.WebhookOpts{
+			Subscribers:    subscribers,
+			DBPath:         ko.String("webhook.db_path"),
+			Timeout:        time.Duration(ko.Int("webhook.timeout_secs")) * time.Second,
+			MaxAttempts:    ko.Int("webhook.max_attempts"),
+			InitialBackoff: time.Duration(ko.Int("webhook.initial_backoff_secs")) * time.Second,
+			MaxBackoff:     time.Duration(ko.Int("webhook.max_backoff_secs")) * time.Second,
+			RetryInterval:  time.Duration(ko.Int("webhook.retry_interval_secs")) * time.Second,
+			QueueSize:      ko.Int("webhook.queue_size"),
+			Logg:           lo,
+		})
+		if err != nil {
+			closeSinks(enabled)
+			return nil, err
+		}
+		enabled = append(enabled, fanoutSink("webhook", webhookPub))
+		lo.Debug("loaded webhook publisher")
+	}
+
+	if ko.Bool("archive.enable") {
+		archivePub, err := pub.NewArchivePub(pub.ArchiveOpts{
+			Dir:            ko.MustString("archive.dir"),
+			Format:         ko.String("archive.format"),
+			MaxEvents:      ko.Int("archive.max_events"),
+			RotateInterval: time.Duration(ko.Int("archive.rotate_interval_mins")) * time.Minute,
+			MaxOpenFiles:   ko.Int("archive.max_open_files"),
+			Logg:           lo,
+		})
+		if err != nil {
+			closeSinks(enabled)
+			return nil, err
+		}
+		enabled = append(enabled, fanoutSink("archive", archivePub))
+		lo.Debug("loaded archive publisher")
+	}
+
+	switch len(enabled) {
+	case 0:
+		return nil, errors.New("no publisher enabled")
+	case 1:
+		return enabled[0].Pub, nil
+	default:
+		fanoutPub, err := pub.NewFanoutPub(pub.FanoutOpts{
+			Sinks: enabled,
+			Logg:  lo,
+		})
+		if err != nil {
+			closeSinks(enabled)
+			return nil, err
+		}
+		lo.Debug("loaded fanout publisher", "sinks", len(enabled))
+
+		return fanoutPub, nil
+	}
+}
+
+func fanoutSink(name string, p pub.Pub) pub.FanoutSink {
+	return pub.FanoutSink{
+		Name:       name,
+		Pub:        p,
+		Policy:     ko.String(name + ".policy"),
+		BufferSize: ko.Int(name + ".buffer_size"),
+		MaxRetries: ko.Int(name + ".max_retries"),
+		BufferWait: time.Duration(ko.Int(name+".buffer_wait_secs")) * time.Second,
+	}
+}
+
+func closeSinks(sinks []pub.FanoutSink) {
+	for _, s := range sinks {
+		s.Pub.Close()
+	}
+}
@@ -2,13 +2,14 @@ package main
 
 import (
 	"github.com/grassrootseconomics/eth-tracker/internal/cache"
+	"github.com/grassrootseconomics/eth-tracker/internal/chain"
 	"github.com/grassrootseconomics/eth-tracker/internal/handler"
 	"github.com/grassrootseconomics/eth-tracker/pkg/router"
 	"github.com/lmittmann/w3"
 )
 
-func bootstrapEventRouter(cacheProvider cache.Cache, pubCB router.Callback) *router.Router {
-	handlerContainer := handler.New(cacheProvider)
+func bootstrapEventRouter(cacheProvider cache.Cache, chainProvider chain.Chain, pubCB router.Callback) *router.Router {
+	handlerContainer := handler.New(cacheProvider, chainProvider)
 	router := router.New(pubCB)
 
 	router.RegisterContractCreationHandler(handler.HandleContractCreation(handlerContainer))
@@ -27,7 +28,13 @@ func bootstrapEventRouter(cacheProvider cache.Cache, pubCB router.Callback) *rou
 	router.RegisterLogRoute(w3.H("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"), handler.HandleTokenTransferLog(handlerContainer))
 	router.RegisterLogRoute(w3.H("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"), handler.HandleTokenApproveLog(handlerContainer))
 	router.RegisterLogRoute(w3.H("0x5f7542858008eeb041631f30e6109ae94b83a58e9a58261dd2c42c508850f939"), handler.HandleTokenTransferFromLog(handlerContainer))
+	router.RegisterLogRoute(w3.H("0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31"), handler.HandleApprovalForAllLog(handlerContainer))
 	router.RegisterLogRoute(w3.H("0x06526a30af2ff868c2686df12e95844d8ae300416bbec5d5ccc2d2f4afdb17a0"), handler.HandleQuoterUpdatedLog())
+	router.RegisterLogRoute(w3.H("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"), handler.HandleMultiTokenTransferSingleLog(handlerContainer))
+	router.RegisterLogRoute(w3.H("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb"), handler.HandleMultiTokenTransferBatchLog(handlerContainer))
+	router.RegisterLogRoute(w3.H("0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f"), handler.HandleUserOperationLog(handlerContainer))
+	router.RegisterLogRoute(w3.H("0xd51a9c61267aa6196961883ecf5ff2da6619c37dac0fa92122513fb32c032d2d"), handler.HandleAccountDeployedLog(handlerContainer))
+	router.RegisterLogRoute(w3.H("0x1c4fada7374c0a9ee8841fc38afe82932dc0f8e69012e927f061a8bae611a201"), handler.HandleUserOperationRevertReasonLog(handlerContainer))
 
 	router.RegisterInputDataRoute("63e4bff4", handler.HandleFaucetGiveInputData())
 	router.RegisterInputDataRoute("de82efb4", handler.HandleFaucetGiveInputData())
@@ -46,6 +53,14 @@ func bootstrapEventRouter(cacheProvider cache.Cache, pubCB router.Callback) *rou
 	router.RegisterInputDataRoute("23b872dd", handler.HandleTokenTransferInputData(handlerContainer))
 	router.RegisterInputDataRoute("095ea7b3", handler.HandleTokenApproveInputData(handlerContainer))
 	router.RegisterInputDataRoute("f912c64b", handler.HandleQuoterUpdatedInputData())
+	router.RegisterInputDataRoute("42842e0e", handler.HandleNFTTransferInputData(handlerContainer))
+	router.RegisterInputDataRoute("b88d4fde", handler.HandleNFTTransferInputData(handlerContainer))
+	router.RegisterInputDataRoute("a22cb465", handler.HandleApprovalForAllInputData(handlerContainer))
+	router.RegisterInputDataRoute("f242432a", handler.HandleMultiTokenTransferInputData(handlerContainer))
+	router.RegisterInputDataRoute("2eb2c2d6", handler.HandleMultiTokenTransferInputData(handlerContainer))
+	router.RegisterInputDataRoute("d505accf", handler.HandleTokenPermitInputData(handlerContainer))
+
+	router.RegisterSuccessInputDataRoute("d505accf", handler.HandleTokenPermitInputData(handlerContainer))
 
 	return router
 }
@@ -3,6 +3,7 @@ package db
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 
 	"github.com/bits-and-blooms/bitset"
@@ -16,6 +17,10 @@ type boltDB struct {
 const (
 	dbFolderName = "db/tracker_db"
 
+	blocksBucket     = "blocks"
+	outboxBucket     = "outbox"
+	deadLetterBucket = "dead_letters"
+
 	upperBoundKey = "upper"
 	lowerBoundKey = "lower"
 )
@@ -29,9 +34,10 @@ func NewBoltDB() (DB, error) {
 	}
 
 	db.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists([]byte("blocks"))
-		if err != nil {
-			return fmt.Errorf("create bucket: %s", err)
+		for _, bucket := range []string{blocksBucket, outboxBucket, deadLetterBucket} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(bucket)); err != nil {
+				return fmt.Errorf("create bucket: %s", err)
+			}
 		}
 		return nil
 	})
@@ -48,7 +54,7 @@ func (d *boltDB) Close() error {
 func (d *boltDB) get(k string) ([]byte, error) {
 	var v []byte
 	err := d.db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("blocks"))
+		b := tx.Bucket([]byte(blocksBucket))
 		v = b.Get([]byte(k))
 		return nil
 	})
@@ -62,7 +68,7 @@ func (d *boltDB) get(k string) ([]byte, error) {
 
 func (d *boltDB) setUint64(k string, v uint64) error {
 	err := d.db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("blocks"))
+		b := tx.Bucket([]byte(blocksBucket))
 		return b.Put([]byte(k), marshalUint64(v))
 	})
 	if err != nil {
@@ -73,7 +79,7 @@ func (d *boltDB) setUint64(k string, v uint64) error {
 
 func (d *boltDB) setUint64AsKey(v uint64) error {
 	err := d.db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("blocks"))
+		b := tx.Bucket([]byte(blocksBucket))
 		return b.Put(marshalUint64(v), nil)
 	})
 	if err != nil {
@@ -138,10 +144,12 @@ func (d *boltDB) GetMissingValuesBitSet(lowerBound uint64, upperBound uint64) (*
 			b.Set(uint(i))
 		}
 
-		c := tx.Bucket([]byte("blocks")).Cursor()
+		for _, bucket := range []string{blocksBucket, deadLetterBucket} {
+			c := tx.Bucket([]byte(bucket)).Cursor()
 
-		for k, _ := c.Seek(lowerRaw); k != nil && bytes.Compare(k, upperRaw) <= 0; k, _ = c.Next() {
-			b.Clear(uint(unmarshalUint64(k)))
+			for k, _ := c.Seek(lowerRaw); k != nil && bytes.Compare(k, upperRaw) <= 0; k, _ = c.Next() {
+				b.Clear(uint(unmarshalUint64(k)))
+			}
 		}
 
 		return nil
@@ -161,7 +169,7 @@ func (d *boltDB) Cleanup() error {
 	target := marshalUint64(lowerBound - 1)
 
 	err = d.db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket([]byte("blocks"))
+		b := tx.Bucket([]byte(blocksBucket))
 		c := b.Cursor()
 
 		for k, _ := c.First(); k != nil && bytes.Compare(k, target) <= 0; k, _ = c.Next() {
@@ -178,3 +186,119 @@ func (d *boltDB) Cleanup() error {
 
 	return nil
 }
+
+func (d *boltDB) CommitBlock(block uint64, events [][]byte) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		outbox := tx.Bucket([]byte(outboxBucket))
+
+		for _, data := range events {
+			// Sequence keys keep the outbox in processing order
+			seq, err := outbox.NextSequence()
+			if err != nil {
+				return err
+			}
+			if err := outbox.Put(marshalUint64(seq), data); err != nil {
+				return err
+			}
+		}
+
+		return tx.Bucket([]byte(blocksBucket)).Put(marshalUint64(block), nil)
+	})
+}
+
+func (d *boltDB) ReadOutbox(limit int) ([]OutboxEntry, error) {
+	var entries []OutboxEntry
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(outboxBucket)).Cursor()
+
+		for k, v := c.First(); k != nil && len(entries) < limit; k, v = c.Next() {
+			entries = append(entries, OutboxEntry{
+				Key:  unmarshalUint64(k),
+				Data: bytes.Clone(v),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (d *boltDB) DeleteOutbox(keys ...uint64) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(outboxBucket))
+
+		for _, k := range keys {
+			if err := b.Delete(marshalUint64(k)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (d *boltDB) OutboxSize() (int, error) {
+	var size int
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		size = tx.Bucket([]byte(outboxBucket)).Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+func (d *boltDB) PutDeadLetter(deadLetter DeadLetter) error {
+	data, err := json.Marshal(deadLetter)
+	if err != nil {
+		return err
+	}
+
+	return d.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(deadLetterBucket)).Put(marshalUint64(deadLetter.Block), data)
+	})
+}
+
+func (d *boltDB) GetDeadLetters() ([]DeadLetter, error) {
+	var deadLetters []DeadLetter
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(deadLetterBucket)).ForEach(func(_, v []byte) error {
+			var deadLetter DeadLetter
+			if err := json.Unmarshal(v, &deadLetter); err != nil {
+				return err
+			}
+			deadLetters = append(deadLetters, deadLetter)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+func (d *boltDB) DeleteDeadLetter(block uint64) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(deadLetterBucket)).Delete(marshalUint64(block))
+	})
+}
+
+func (d *boltDB) DiscardDeadLetter(block uint64) error {
+	return d.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket([]byte(deadLetterBucket)).Delete(marshalUint64(block)); err != nil {
+			return err
+		}
+
+		return tx.Bucket([]byte(blocksBucket)).Put(marshalUint64(block), nil)
+	})
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/bits-and-blooms/bitset"
 )
@@ -16,6 +17,30 @@ type (
 		SetValue(uint64) error
 		GetMissingValuesBitSet(uint64, uint64) (*bitset.BitSet, error)
 		Cleanup() error
+		// CommitBlock stages the serialized events of a block in the outbox and marks the block as processed in a single transaction
+		CommitBlock(uint64, [][]byte) error
+		ReadOutbox(int) ([]OutboxEntry, error)
+		DeleteOutbox(...uint64) error
+		OutboxSize() (int, error)
+		// Dead lettered blocks are excluded from the missing values until they are deleted
+		PutDeadLetter(DeadLetter) error
+		GetDeadLetters() ([]DeadLetter, error)
+		DeleteDeadLetter(uint64) error
+		// DiscardDeadLetter deletes the dead letter and marks the block as processed so that it is never backfilled
+		DiscardDeadLetter(uint64) error
+	}
+
+	DeadLetter struct {
+		Block    uint64    `json:"block"`
+		Attempts int       `json:"attempts"`
+		Class    string    `json:"class"`
+		Error    string    `json:"error"`
+		FailedAt time.Time `json:"failedAt"`
+	}
+
+	OutboxEntry struct {
+		Key  uint64
+		Data []byte
 	}
 
 	DBOpts struct {
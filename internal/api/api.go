@@ -4,13 +4,21 @@ import (
 	"net/http"
 
 	"github.com/VictoriaMetrics/metrics"
+	"github.com/grassrootseconomics/eth-tracker/internal/pool"
 	"github.com/uptrace/bunrouter"
 )
 
-func New() *bunrouter.Router {
+type APIOpts struct {
+	Pool *pool.Pool
+}
+
+func New(o APIOpts) *bunrouter.Router {
 	router := bunrouter.New()
 
 	router.GET("/metrics", metricsHandler())
+	router.GET("/dead-letters", deadLettersHandler(o.Pool))
+	router.POST("/dead-letters/:block/requeue", requeueHandler(o.Pool))
+	router.DELETE("/dead-letters/:block", discardHandler(o.Pool))
 	return router
 }
 
@@ -20,3 +28,56 @@ func metricsHandler() bunrouter.HandlerFunc {
 		return nil
 	}
 }
+
+func deadLettersHandler(workerPool *pool.Pool) bunrouter.HandlerFunc {
+	return func(w http.ResponseWriter, _ bunrouter.Request) error {
+		deadLetters, err := workerPool.DeadLetters()
+		if err != nil {
+			return err
+		}
+
+		return bunrouter.JSON(w, bunrouter.H{
+			"deadLetters": deadLetters,
+		})
+	}
+}
+
+func requeueHandler(workerPool *pool.Pool) bunrouter.HandlerFunc {
+	return func(w http.ResponseWriter, req bunrouter.Request) error {
+		block, err := req.Params().Uint64("block")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return bunrouter.JSON(w, bunrouter.H{
+				"error": "invalid block number",
+			})
+		}
+
+		if err := workerPool.Requeue(block); err != nil {
+			return err
+		}
+
+		return bunrouter.JSON(w, bunrouter.H{
+			"requeued": block,
+		})
+	}
+}
+
+func discardHandler(workerPool *pool.Pool) bunrouter.HandlerFunc {
+	return func(w http.ResponseWriter, req bunrouter.Request) error {
+		block, err := req.Params().Uint64("block")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return bunrouter.JSON(w, bunrouter.H{
+				"error": "invalid block number",
+			})
+		}
+
+		if err := workerPool.Discard(block); err != nil {
+			return err
+		}
+
+		return bunrouter.JSON(w, bunrouter.H{
+			"discarded": block,
+		})
+	}
+}
@@ -55,13 +55,13 @@ func (b *Backfill) Start() {
 			b.logg.Debug("backfill shutting down")
 			return
 		case <-b.ticker.C:
-			if b.pool.Size() <= 1 {
+			if b.pool.LaneSize(pool.LaneBackfill) <= 1 {
 				if err := b.Run(true); err != nil {
 					b.logg.Error("backfill run error", "err", err)
 				}
-				b.logg.Debug("backfill successful run", "queue_size", b.pool.Size())
+				b.logg.Debug("backfill successful run", "queue_size", b.pool.LaneSize(pool.LaneBackfill))
 			} else {
-				b.logg.Debug("skipping backfill tick", "queue_size", b.pool.Size())
+				b.logg.Debug("skipping backfill tick", "queue_size", b.pool.LaneSize(pool.LaneBackfill))
 			}
 		}
 	}
@@ -101,7 +101,7 @@ func (b *Backfill) Run(skipLatest bool) error {
 					break
 				}
 
-				b.pool.Push(uint64(buffer[k]))
+				b.pool.Push(uint64(buffer[k]), pool.LaneBackfill)
 				b.logg.Debug("pushed block from backfill", "block", buffer[k])
 				pushedCount++
 			}
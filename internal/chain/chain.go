@@ -14,7 +14,8 @@ type Chain interface {
 	GetBlock(context.Context, uint64) (*types.Block, error)
 	GetLatestBlock(context.Context) (uint64, error)
 	GetTransaction(context.Context, common.Hash) (*types.Transaction, error)
-	GetReceipts(context.Context, *big.Int) (types.Receipts, error)
+	GetReceipts(context.Context, *big.Int) ([]*Receipt, error)
+	GetRevertData(context.Context, *types.Transaction, common.Address, *big.Int) ([]byte, error)
 	// Expose provider until we eject from celoutils
 	Provider() *ethutils.Provider
 }
@@ -2,11 +2,13 @@ package chain
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"net/http"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/grassrootseconomics/ethutils"
@@ -22,7 +24,8 @@ type (
 	}
 
 	EthRPC struct {
-		provider *ethutils.Provider
+		provider    *ethutils.Provider
+		feeCurrency bool
 	}
 )
 
@@ -39,7 +42,8 @@ func NewRPCFetcher(o EthRPCOpts) (Chain, error) {
 	)
 
 	return &EthRPC{
-		provider: chainProvider,
+		provider:    chainProvider,
+		feeCurrency: feeCurrencyChains[o.ChainID],
 	}, nil
 }
 
@@ -103,16 +107,64 @@ func (c *EthRPC) GetTransaction(ctx context.Context, txHash common.Hash) (*types
 	return transaction, nil
 }
 
-func (c *EthRPC) GetReceipts(ctx context.Context, blockNumber *big.Int) (types.Receipts, error) {
-	var receipts types.Receipts
+func (c *EthRPC) GetReceipts(ctx context.Context, blockNumber *big.Int) ([]*Receipt, error) {
+	var receipts []*Receipt
 
-	if err := c.provider.Client.CallCtx(ctx, eth.BlockReceipts(blockNumber).Returns(&receipts)); err != nil {
+	if !c.feeCurrency {
+		if err := c.provider.Client.CallCtx(ctx, &blockReceiptsCaller{blockNumber: blockNumber, receipts: &receipts}); err != nil {
+			return nil, err
+		}
+
+		return receipts, nil
+	}
+
+	// The fee currency is a field of the transaction, fetch the block in the same batch to match it to the receipts
+	feeCurrencies := &blockFeeCurrenciesCaller{blockNumber: blockNumber}
+	if err := c.provider.Client.CallCtx(ctx, &blockReceiptsCaller{blockNumber: blockNumber, receipts: &receipts}, feeCurrencies); err != nil {
 		return nil, err
 	}
+	if feeCurrencies.block != nil {
+		setFeeCurrencies(receipts, feeCurrencies.block.Transactions)
+	}
 
 	return receipts, nil
 }
 
+// GetRevertData replays the transaction with eth_call, nil revert data means the node returned none
+func (c *EthRPC) GetRevertData(ctx context.Context, tx *types.Transaction, from common.Address, blockNumber *big.Int) ([]byte, error) {
+	var output []byte
+
+	msg := &w3types.Message{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Input: tx.Data(),
+	}
+
+	err := c.provider.Client.CallCtx(ctx, eth.Call(msg, blockNumber, nil).Returns(&output))
+	if err == nil {
+		return nil, nil
+	}
+
+	var callErrs w3.CallErrors
+	if !errors.As(err, &callErrs) || len(callErrs) == 0 || callErrs[0] == nil {
+		return nil, err
+	}
+
+	var dataErr rpc.DataError
+	if !errors.As(callErrs[0], &dataErr) {
+		return nil, nil
+	}
+
+	revertData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, nil
+	}
+
+	return hexutil.Decode(revertData)
+}
+
 func (c *EthRPC) Provider() *ethutils.Provider {
 	return c.provider
 }
@@ -0,0 +1,103 @@
+package chain
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type (
+	// Receipt carries chain specific fee fields that are dropped by the upstream receipt type
+	Receipt struct {
+		*types.Receipt
+		// Celo fee abstraction, read from the CIP-64 transaction since receipts do not carry it
+		FeeCurrency *common.Address
+		// OP stack rollups
+		L1Fee *big.Int
+	}
+
+	blockReceiptsCaller struct {
+		blockNumber *big.Int
+		receipts    *[]*Receipt
+	}
+
+	feeCurrencyTx struct {
+		Hash        common.Hash     `json:"hash"`
+		FeeCurrency *common.Address `json:"feeCurrency"`
+	}
+
+	blockFeeCurrenciesCaller struct {
+		blockNumber *big.Int
+		block       *struct {
+			Transactions []feeCurrencyTx `json:"transactions"`
+		}
+	}
+)
+
+// Celo mainnet, Alfajores, Baklava and Celo Sepolia
+var feeCurrencyChains = map[int64]bool{
+	42220:    true,
+	44787:    true,
+	62320:    true,
+	11142220: true,
+}
+
+func (r *Receipt) UnmarshalJSON(input []byte) error {
+	r.Receipt = new(types.Receipt)
+	if err := r.Receipt.UnmarshalJSON(input); err != nil {
+		return err
+	}
+
+	var extra struct {
+		L1Fee *hexutil.Big `json:"l1Fee"`
+	}
+	if err := json.Unmarshal(input, &extra); err != nil {
+		return err
+	}
+
+	r.L1Fee = (*big.Int)(extra.L1Fee)
+
+	return nil
+}
+
+func (c *blockReceiptsCaller) CreateRequest() (rpc.BatchElem, error) {
+	return rpc.BatchElem{
+		Method: "eth_getBlockReceipts",
+		Args:   []any{hexutil.EncodeBig(c.blockNumber)},
+		Result: c.receipts,
+	}, nil
+}
+
+func (c *blockReceiptsCaller) HandleResponse(elem rpc.BatchElem) error {
+	return elem.Error
+}
+
+func (c *blockFeeCurrenciesCaller) CreateRequest() (rpc.BatchElem, error) {
+	return rpc.BatchElem{
+		Method: "eth_getBlockByNumber",
+		Args:   []any{hexutil.EncodeBig(c.blockNumber), true},
+		Result: &c.block,
+	}, nil
+}
+
+func (c *blockFeeCurrenciesCaller) HandleResponse(elem rpc.BatchElem) error {
+	return elem.Error
+}
+
+// setFeeCurrencies copies the fee currency of every CIP-64 transaction in the block to its receipt
+func setFeeCurrencies(receipts []*Receipt, transactions []feeCurrencyTx) {
+	feeCurrencies := make(map[common.Hash]*common.Address, len(transactions))
+	for _, tx := range transactions {
+		if tx.FeeCurrency != nil {
+			feeCurrencies[tx.Hash] = tx.FeeCurrency
+		}
+	}
+
+	for _, receipt := range receipts {
+		receipt.FeeCurrency = feeCurrencies[receipt.TxHash]
+	}
+}
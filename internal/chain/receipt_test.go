@@ -0,0 +1,104 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/stretchr/testify/require"
+)
+
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+// newFixtureRPC serves eth_getBlockReceipts and eth_getBlockByNumber from the CIP-64 fixtures and records the requested methods
+func newFixtureRPC(t *testing.T, methods *[]string) string {
+	fixtures := map[string]json.RawMessage{
+		"eth_getBlockByNumber": readFixture(t, "testdata/cip64_block.json"),
+		"eth_getBlockReceipts": readFixture(t, "testdata/cip64_receipts.json"),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var (
+			requests []rpcRequest
+			batch    = bytes.HasPrefix(bytes.TrimSpace(body), []byte("["))
+		)
+		if batch {
+			require.NoError(t, json.Unmarshal(body, &requests))
+		} else {
+			requests = make([]rpcRequest, 1)
+			require.NoError(t, json.Unmarshal(body, &requests[0]))
+		}
+
+		responses := make([]map[string]any, len(requests))
+		for i, req := range requests {
+			result, ok := fixtures[req.Method]
+			require.True(t, ok, req.Method)
+			*methods = append(*methods, req.Method)
+
+			responses[i] = map[string]any{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"result":  result,
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if batch {
+			require.NoError(t, json.NewEncoder(w).Encode(responses))
+		} else {
+			require.NoError(t, json.NewEncoder(w).Encode(responses[0]))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func readFixture(t *testing.T, path string) json.RawMessage {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return data
+}
+
+func TestGetReceipts_FeeCurrencyFromCIP64Transaction(t *testing.T) {
+	var methods []string
+	rpcFetcher, err := NewRPCFetcher(EthRPCOpts{RPCEndpoint: newFixtureRPC(t, &methods), ChainID: 42220})
+	require.NoError(t, err)
+
+	receipts, err := rpcFetcher.GetReceipts(context.Background(), big.NewInt(32_029_888))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"eth_getBlockReceipts", "eth_getBlockByNumber"}, methods)
+
+	require.Len(t, receipts, 2)
+	require.Equal(t, uint8(0x7b), receipts[0].Type)
+	require.NotNil(t, receipts[0].FeeCurrency)
+	require.Equal(t, w3.A("0x765DE816845861e75A25fCA122bb6898B8B1282a"), *receipts[0].FeeCurrency)
+	// Transactions paying in the native token have no fee currency
+	require.Nil(t, receipts[1].FeeCurrency)
+}
+
+func TestGetReceipts_SkipsFeeCurrencyOutsideCelo(t *testing.T) {
+	var methods []string
+	rpcFetcher, err := NewRPCFetcher(EthRPCOpts{RPCEndpoint: newFixtureRPC(t, &methods), ChainID: 1})
+	require.NoError(t, err)
+
+	receipts, err := rpcFetcher.GetReceipts(context.Background(), big.NewInt(32_029_888))
+	require.NoError(t, err)
+	require.Equal(t, []string{"eth_getBlockReceipts"}, methods)
+
+	require.Len(t, receipts, 2)
+	require.Nil(t, receipts[0].FeeCurrency)
+}
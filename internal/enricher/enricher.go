@@ -0,0 +1,137 @@
+package enricher
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/internal/chain"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+)
+
+type (
+	EnricherOpts struct {
+		Chain     chain.Chain
+		CacheSize int
+		// MaxEventAge skips events older than the age, metadata is read at the latest block and may not match
+		// historical events, e.g. during a backfill. Zero enriches every event
+		MaxEventAge time.Duration
+		Logg        *slog.Logger
+	}
+
+	Enricher struct {
+		logg        *slog.Logger
+		store       *metadataStore
+		maxEventAge time.Duration
+	}
+)
+
+const defaultCacheSize = 4096
+
+// Events that may indicate a token's metadata has changed
+var contractInvalidationEvents = map[string]bool{
+	"OWNERSHIP_TRANSFERRED": true,
+	"SEAL_STATE_CHANGE":     true,
+}
+
+func New(o EnricherOpts) *Enricher {
+	if o.CacheSize <= 0 {
+		o.CacheSize = defaultCacheSize
+	}
+
+	return &Enricher{
+		logg:        o.Logg,
+		store:       newMetadataStore(o.Chain, o.CacheSize),
+		maxEventAge: o.MaxEventAge,
+	}
+}
+
+// Callback wraps the next callback in the chain with the enrichment stage
+func (e *Enricher) Callback(next router.Callback) router.Callback {
+	return func(ctx context.Context, ev event.Event) error {
+		e.invalidate(ev)
+
+		payload, ok := ev.Payload.(event.TokenValuePayload)
+		if !ok || e.historical(ev) {
+			return next(ctx, ev)
+		}
+
+		// A failed lookup fails the block so that the retry publishes the event with its metadata
+		metadata, err := e.store.get(ctx, ev.ContractAddress)
+		if err != nil {
+			return fmt.Errorf("token metadata fetch error: token %s: %w", ev.ContractAddress, err)
+		}
+		if !metadata.Valid {
+			return next(ctx, ev)
+		}
+
+		payload.SetTokenMetadata(
+			event.TokenMetadata{
+				Name:     metadata.Name,
+				Symbol:   metadata.Symbol,
+				Decimals: metadata.Decimals,
+			},
+			normalizeAmount(payload.RawValue(), metadata.Decimals),
+		)
+
+		return next(ctx, ev)
+	}
+}
+
+func (e *Enricher) historical(ev event.Event) bool {
+	if e.maxEventAge <= 0 {
+		return false
+	}
+
+	return time.Since(time.Unix(int64(ev.Timestamp), 0)) > e.maxEventAge
+}
+
+func (e *Enricher) invalidate(ev event.Event) {
+	if !ev.Success {
+		return
+	}
+
+	if contractInvalidationEvents[ev.TxType] {
+		e.store.invalidate(ev.ContractAddress)
+	}
+
+	switch payload := ev.Payload.(type) {
+	case *event.IndexAddPayload:
+		e.store.invalidate(payload.Address)
+	case *event.IndexRemovePayload:
+		e.store.invalidate(payload.Address)
+	}
+}
+
+func normalizeAmount(value string, decimals uint8) string {
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return value
+	}
+	if decimals == 0 {
+		return amount.String()
+	}
+
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+		amount.Abs(amount)
+	}
+
+	digits := amount.String()
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+
+	integerPart := digits[:len(digits)-int(decimals)]
+	fractionalPart := strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+	if fractionalPart == "" {
+		return sign + integerPart
+	}
+
+	return sign + integerPart + "." + fractionalPart
+}
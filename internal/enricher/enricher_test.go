@@ -0,0 +1,93 @@
+package enricher
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/internal/chain"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/ethutils"
+	"github.com/stretchr/testify/require"
+)
+
+type testChain struct {
+	chain.Chain
+	provider *ethutils.Provider
+}
+
+func (c *testChain) Provider() *ethutils.Provider {
+	return c.provider
+}
+
+func TestNormalizeAmount(t *testing.T) {
+	tests := []struct {
+		value    string
+		decimals uint8
+		want     string
+	}{
+		{"1500000", 6, "1.5"},
+		{"1000000", 6, "1"},
+		{"5", 6, "0.000005"},
+		{"-2500", 3, "-2.5"},
+		{"42", 0, "42"},
+		{"not-a-number", 6, "not-a-number"},
+	}
+
+	for _, tc := range tests {
+		require.Equal(t, tc.want, normalizeAmount(tc.value, tc.decimals), tc.value)
+	}
+}
+
+func TestCallback_SkipsHistoricalEvents(t *testing.T) {
+	// The chain is never queried for events past the max age
+	enricher := New(EnricherOpts{
+		MaxEventAge: time.Hour,
+		Logg:        slog.Default(),
+	})
+
+	var published event.Event
+	err := enricher.Callback(func(_ context.Context, ev event.Event) error {
+		published = ev
+		return nil
+	})(context.Background(), event.Event{
+		Timestamp: uint64(time.Now().Add(-2 * time.Hour).Unix()),
+		TxType:    "TOKEN_TRANSFER",
+		Payload:   &event.TokenTransferPayload{Value: "100"},
+	})
+	require.NoError(t, err)
+	require.Nil(t, published.Payload.(*event.TokenTransferPayload).Token)
+}
+
+func TestCallback_FailsOnMetadataLookupError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	enricher := New(EnricherOpts{
+		Chain: &testChain{provider: ethutils.NewProvider(server.URL, 1)},
+		Logg:  slog.Default(),
+	})
+
+	// The block is retried instead of publishing the event without metadata
+	var called bool
+	err := enricher.Callback(func(_ context.Context, _ event.Event) error {
+		called = true
+		return nil
+	})(context.Background(), event.Event{
+		Timestamp:       uint64(time.Now().Unix()),
+		TxType:          "TOKEN_TRANSFER",
+		ContractAddress: "0x765DE816845861e75A25fCA122bb6898B8B1282a",
+		Payload:         &event.TokenTransferPayload{Value: "100"},
+	})
+	require.ErrorContains(t, err, "token metadata fetch error")
+	require.False(t, called)
+
+	// Failed lookups are not cached
+	_, cached := enricher.store.lru.Get("0x765DE816845861e75A25fCA122bb6898B8B1282a")
+	require.False(t, cached)
+}
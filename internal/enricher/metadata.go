@@ -0,0 +1,76 @@
+package enricher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/grassrootseconomics/eth-tracker/internal/chain"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+)
+
+type (
+	TokenMetadata struct {
+		Name     string
+		Symbol   string
+		Decimals uint8
+		// Valid is false for contracts that do not expose ERC-20 metadata
+		Valid bool
+	}
+
+	metadataStore struct {
+		chain chain.Chain
+		lru   *lru.Cache[string, TokenMetadata]
+	}
+)
+
+var (
+	nameGetter     = w3.MustNewFunc("name()", "string")
+	symbolGetter   = w3.MustNewFunc("symbol()", "string")
+	decimalsGetter = w3.MustNewFunc("decimals()", "uint8")
+)
+
+func newMetadataStore(chain chain.Chain, size int) *metadataStore {
+	return &metadataStore{
+		chain: chain,
+		lru:   lru.NewCache[string, TokenMetadata](size),
+	}
+}
+
+func (s *metadataStore) get(ctx context.Context, token string) (TokenMetadata, error) {
+	if metadata, ok := s.lru.Get(token); ok {
+		return metadata, nil
+	}
+
+	var (
+		metadata     TokenMetadata
+		tokenAddress = common.HexToAddress(token)
+	)
+
+	err := s.chain.Provider().Client.CallCtx(
+		ctx,
+		eth.CallFunc(tokenAddress, decimalsGetter).Returns(&metadata.Decimals),
+		eth.CallFunc(tokenAddress, nameGetter).Returns(&metadata.Name),
+		eth.CallFunc(tokenAddress, symbolGetter).Returns(&metadata.Symbol),
+	)
+	if err != nil {
+		var callErrs w3.CallErrors
+		if !errors.As(err, &callErrs) {
+			return metadata, err
+		}
+
+		// Decimals are required for normalization, name and symbol are optional
+		metadata.Valid = callErrs[0] == nil
+	} else {
+		metadata.Valid = true
+	}
+
+	s.lru.Add(token, metadata)
+	return metadata, nil
+}
+
+func (s *metadataStore) invalidate(token string) {
+	s.lru.Remove(token)
+}
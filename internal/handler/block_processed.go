@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+)
+
+const blockProcessedEventName = "BLOCK_PROCESSED"
+
+// HandleBlockProcessed publishes the marker that follows the events of a block, it is also published for blocks without events
+func HandleBlockProcessed(ctx context.Context, block *types.Block, eventCount uint, c router.Callback) error {
+	blockProcessedEvent := event.Event{
+		Block:     block.NumberU64(),
+		BlockHash: block.Hash().Hex(),
+		// The marker is not tied to a contract, the zero address keeps {contract} subjects publishable
+		ContractAddress: common.Address{}.Hex(),
+		Success:         true,
+		Timestamp:       block.Time(),
+		TxType:          blockProcessedEventName,
+		Payload: &event.BlockProcessedPayload{
+			EventCount: eventCount,
+		},
+	}
+
+	return c(ctx, blockProcessedEvent)
+}
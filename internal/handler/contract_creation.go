@@ -18,8 +18,8 @@ func HandleContractCreation(hc *HandlerContainer) router.ContractCreationHandler
 			Timestamp:       ccp.Timestamp,
 			TxHash:          ccp.TxHash,
 			TxType:          contractCreationEventName,
-			Payload: map[string]any{
-				"from": ccp.From,
+			Payload: &event.ContractCreationPayload{
+				From: ccp.From,
 			},
 		}
 
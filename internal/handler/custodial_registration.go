@@ -21,7 +21,7 @@ func HandleCustodialRegistrationLog() router.LogHandlerFunc {
 		var account common.Address
 
 		if err := custodialRegistrationEvent.DecodeArgs(lp.Log, &account); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		custodialRegistrationEvent := event.Event{
@@ -32,8 +32,8 @@ func HandleCustodialRegistrationLog() router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          custodialRegistrationEventName,
-			Payload: map[string]any{
-				"account": account.Hex(),
+			Payload: &event.CustodialRegistrationPayload{
+				Account: account.Hex(),
 			},
 		}
 
@@ -46,7 +46,7 @@ func HandleCustodialRegistrationInputData() router.InputDataHandlerFunc {
 		var account common.Address
 
 		if err := custodialRegistrationSig.DecodeArgs(w3.B(idp.InputData), &account); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		custodialRegistrationEvent := event.Event{
@@ -56,8 +56,8 @@ func HandleCustodialRegistrationInputData() router.InputDataHandlerFunc {
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          custodialRegistrationEventName,
-			Payload: map[string]any{
-				"account": account.Hex(),
+			Payload: &event.CustodialRegistrationPayload{
+				Account: account.Hex(),
 			},
 		}
 
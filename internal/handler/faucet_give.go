@@ -28,7 +28,7 @@ func HandleFaucetGiveLog() router.LogHandlerFunc {
 		)
 
 		if err := faucetGiveEvent.DecodeArgs(lp.Log, &recipient, &token, &amount); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		faucetGiveEvent := event.Event{
@@ -39,10 +39,10 @@ func HandleFaucetGiveLog() router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          faucetGiveEventName,
-			Payload: map[string]any{
-				"recipient": recipient.Hex(),
-				"token":     token.Hex(),
-				"amount":    amount.String(),
+			Payload: &event.FaucetGivePayload{
+				Recipient: recipient.Hex(),
+				Token:     token.Hex(),
+				Amount:    amount.String(),
 			},
 		}
 
@@ -66,21 +66,21 @@ func HandleFaucetGiveInputData() router.InputDataHandlerFunc {
 			var to common.Address
 
 			if err := faucetGiveToSig.DecodeArgs(w3.B(idp.InputData), &to); err != nil {
-				return err
+				return &router.DecodeError{Err: err}
 			}
 
-			faucetGiveEvent.Payload = map[string]any{
-				"recipient": to.Hex(),
-				"token":     ethutils.ZeroAddress,
-				"amount":    "0",
+			faucetGiveEvent.Payload = &event.FaucetGivePayload{
+				Recipient: to.Hex(),
+				Token:     ethutils.ZeroAddress.Hex(),
+				Amount:    "0",
 			}
 
 			return c(ctx, faucetGiveEvent)
 		case "de82efb4":
-			faucetGiveEvent.Payload = map[string]any{
-				"recipient": ethutils.ZeroAddress,
-				"token":     ethutils.ZeroAddress,
-				"amount":    "0",
+			faucetGiveEvent.Payload = &event.FaucetGivePayload{
+				Recipient: ethutils.ZeroAddress.Hex(),
+				Token:     ethutils.ZeroAddress.Hex(),
+				Amount:    "0",
 			}
 
 			return c(ctx, faucetGiveEvent)
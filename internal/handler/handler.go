@@ -1,13 +1,22 @@
 package handler
 
-import "github.com/grassrootseconomics/eth-tracker/internal/cache"
+import (
+	"github.com/grassrootseconomics/eth-tracker/internal/cache"
+	"github.com/grassrootseconomics/eth-tracker/internal/chain"
+	"github.com/puzpuzpuz/xsync/v3"
+)
 
 type HandlerContainer struct {
 	cache cache.Cache
+	chain chain.Chain
+	// erc721 remembers which contracts implement ERC-721, the interface of a contract does not change
+	erc721 *xsync.MapOf[string, bool]
 }
 
-func New(cacheProvider cache.Cache) *HandlerContainer {
+func New(cacheProvider cache.Cache, chainProvider chain.Chain) *HandlerContainer {
 	return &HandlerContainer{
-		cache: cacheProvider,
+		cache:  cacheProvider,
+		chain:  chainProvider,
+		erc721: xsync.NewMapOf[string, bool](),
 	}
 }
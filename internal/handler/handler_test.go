@@ -0,0 +1,469 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/grassrootseconomics/eth-tracker/internal/cache"
+	"github.com/grassrootseconomics/eth-tracker/internal/chain"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+	"github.com/grassrootseconomics/ethutils"
+	"github.com/lmittmann/w3"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchemaDir = "../../pkg/event/schema"
+
+var (
+	testContract = w3.A("0x0000000000000000000000000000000000000c01")
+	testAlice    = w3.A("0x000000000000000000000000000000000000a11c")
+	testBob      = w3.A("0x0000000000000000000000000000000000000b0b")
+	testTxHash   = w3.H("0x4d7c0b5b4f5e3a1c3d1f0e2b7a6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b8c")
+	// testNFT reports ERC-721 support through supportsInterface, testContract does not
+	testNFT = w3.A("0x0000000000000000000000000000000000000721")
+)
+
+type (
+	handlerTestCase struct {
+		name string
+		run  func(context.Context, *HandlerContainer, router.Callback) error
+	}
+
+	// testChain only serves the eth_call requests handlers make through the provider
+	testChain struct {
+		chain.Chain
+		provider *ethutils.Provider
+	}
+
+	rpcRequest struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+)
+
+func (c *testChain) Provider() *ethutils.Provider {
+	return c.provider
+}
+
+func newTestHandlerContainer(t *testing.T) *HandlerContainer {
+	ctx := context.Background()
+
+	testCache := cache.NewMapCache()
+	for _, address := range []common.Address{testContract, testNFT, testAlice, testBob} {
+		require.NoError(t, testCache.Add(ctx, address.Hex()))
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var (
+			requests []rpcRequest
+			batch    = bytes.HasPrefix(bytes.TrimSpace(body), []byte("["))
+		)
+		if batch {
+			require.NoError(t, json.Unmarshal(body, &requests))
+		} else {
+			requests = make([]rpcRequest, 1)
+			require.NoError(t, json.Unmarshal(body, &requests[0]))
+		}
+
+		responses := make([]map[string]any, len(requests))
+		for i, req := range requests {
+			require.Equal(t, "eth_call", req.Method)
+
+			var msg struct {
+				To common.Address `json:"to"`
+			}
+			require.NoError(t, json.Unmarshal(req.Params[0], &msg))
+
+			responses[i] = map[string]any{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"result":  hexutil.Encode(common.LeftPadBytes([]byte{boolToByte(msg.To == testNFT)}, 32)),
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if batch {
+			require.NoError(t, json.NewEncoder(w).Encode(responses))
+		} else {
+			require.NoError(t, json.NewEncoder(w).Encode(responses[0]))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return New(testCache, &testChain{provider: ethutils.NewProvider(server.URL, 1)})
+}
+
+func boolToByte(b bool) byte {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestHandlers_MatchJSONSchema(t *testing.T) {
+	ctx := context.Background()
+	hc := newTestHandlerContainer(t)
+
+	schemas := compileSchemas(t)
+	seen := map[string]bool{}
+
+	for _, tc := range handlerTestCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			var published int
+
+			err := tc.run(ctx, hc, func(_ context.Context, ev event.Event) error {
+				published++
+				seen[ev.TxType] = true
+
+				jsonData, err := ev.Serialize()
+				require.NoError(t, err)
+
+				instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(jsonData))
+				require.NoError(t, err)
+
+				schema, ok := schemas[ev.TxType]
+				require.True(t, ok, "no schema for %s", ev.TxType)
+				require.NoError(t, schema.Validate(instance), string(jsonData))
+
+				roundTrip, err := event.Deserialize(jsonData)
+				require.NoError(t, err)
+				require.IsType(t, event.NewPayload(ev.TxType), roundTrip.Payload)
+
+				protoData, err := ev.SerializeAs(event.ContentTypeProtobuf)
+				require.NoError(t, err)
+
+				protoRoundTrip, err := event.DeserializeAs(protoData, event.ContentTypeProtobuf)
+				require.NoError(t, err)
+				require.Equal(t, roundTrip, protoRoundTrip)
+
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, 1, published)
+		})
+	}
+
+	for _, txType := range event.TxTypes() {
+		require.True(t, seen[txType], "no handler test case publishes %s", txType)
+	}
+}
+
+func TestHandleIndexAddInputData_DecodesAddress(t *testing.T) {
+	for _, sig := range []*w3.Func{indexAddSig, indexRegisterSig} {
+		var published event.Event
+
+		err := HandleIndexAddInputData()(context.Background(), testInputData(sig, false, testBob), func(_ context.Context, ev event.Event) error {
+			published = ev
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, testBob.Hex(), published.Payload.(*event.IndexAddPayload).Address)
+	}
+}
+
+func TestHandlers_WrapDecodeErrors(t *testing.T) {
+	hc := newTestHandlerContainer(t)
+	noop := func(context.Context, event.Event) error { return nil }
+
+	// The value is missing from the log data
+	err := HandleTokenTransferLog(hc)(context.Background(), testLog(tokenTransferEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, nil), noop)
+	require.True(t, router.IsDecodeError(err))
+
+	input := testInputData(indexAddSig, false, testBob)
+	input.InputData = input.InputData[:20]
+	err = HandleIndexAddInputData()(context.Background(), input, noop)
+	require.True(t, router.IsDecodeError(err))
+}
+
+func TestHandlers_DispatchERC721(t *testing.T) {
+	ctx := context.Background()
+	hc := newTestHandlerContainer(t)
+
+	publish := func(run func(router.Callback) error) event.Event {
+		var published []event.Event
+		require.NoError(t, run(func(_ context.Context, ev event.Event) error {
+			published = append(published, ev)
+			return nil
+		}))
+		require.Len(t, published, 1)
+
+		return published[0]
+	}
+
+	erc20TransferFrom := publish(func(c router.Callback) error {
+		return HandleTokenTransferInputData(hc)(ctx, testInputData(tokenTransferFromSig, false, testAlice, testBob, big.NewInt(7)), c)
+	})
+	require.Equal(t, transferEventName, erc20TransferFrom.TxType)
+	require.Equal(t, "7", erc20TransferFrom.Payload.(*event.TokenTransferPayload).Value)
+
+	nftTransferFrom := publish(func(c router.Callback) error {
+		return HandleTokenTransferInputData(hc)(ctx, testNFTInputData(tokenTransferFromSig, testAlice, testBob, big.NewInt(7)), c)
+	})
+	require.Equal(t, nftTransferEventName, nftTransferFrom.TxType)
+	require.Equal(t, &event.NFTTransferPayload{From: testAlice.Hex(), To: testBob.Hex(), TokenID: "7"}, nftTransferFrom.Payload)
+
+	erc20Approve := publish(func(c router.Callback) error {
+		return HandleTokenApproveLog(hc)(ctx, testLog(tokenApproveEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, encodeData("uint256", big.NewInt(7))), c)
+	})
+	require.Equal(t, approveEventName, erc20Approve.TxType)
+
+	nftApprove := publish(func(c router.Callback) error {
+		return HandleTokenApproveLog(hc)(ctx, testLog(nftApproveEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob), common.BigToHash(big.NewInt(7))}, nil), c)
+	})
+	require.Equal(t, nftApproveEventName, nftApprove.TxType)
+	require.Equal(t, &event.NFTApprovePayload{Owner: testAlice.Hex(), Approved: testBob.Hex(), TokenID: "7"}, nftApprove.Payload)
+
+	approvalForAll := publish(func(c router.Callback) error {
+		return HandleApprovalForAllLog(hc)(ctx, testLog(approvalForAllEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, encodeData("bool", true)), c)
+	})
+	require.Equal(t, &event.ApprovalForAllPayload{Owner: testAlice.Hex(), Operator: testBob.Hex(), Approved: true}, approvalForAll.Payload)
+}
+
+func TestJSONSchema_UpToDate(t *testing.T) {
+	for _, txType := range event.TxTypes() {
+		schema, err := event.JSONSchema(txType)
+		require.NoError(t, err)
+
+		committed, err := os.ReadFile(filepath.Join(testSchemaDir, txType+".json"))
+		require.NoError(t, err)
+		require.Equal(t, string(append(schema, '\n')), string(committed), "run go generate ./pkg/event")
+	}
+}
+
+func compileSchemas(t *testing.T) map[string]*jsonschema.Schema {
+	compiler := jsonschema.NewCompiler()
+	schemas := map[string]*jsonschema.Schema{}
+
+	for _, txType := range event.TxTypes() {
+		schema, err := compiler.Compile(filepath.Join(testSchemaDir, txType+".json"))
+		require.NoError(t, err)
+		schemas[txType] = schema
+	}
+
+	return schemas
+}
+
+func handlerTestCases() []handlerTestCase {
+	return []handlerTestCase{
+		{"ContractCreation", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleContractCreation(hc)(ctx, router.ContractCreationPayload{
+				From:            testAlice.Hex(),
+				ContractAddress: testContract.Hex(),
+				Block:           1,
+				TxHash:          testTxHash.Hex(),
+				Success:         true,
+			}, c)
+		}},
+		{"CustodialRegistrationLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleCustodialRegistrationLog()(ctx, testLog(custodialRegistrationEvent, []common.Hash{addressTopic(testAlice)}, nil), c)
+		}},
+		{"CustodialRegistrationInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleCustodialRegistrationInputData()(ctx, testInputData(custodialRegistrationSig, false, testAlice), c)
+		}},
+		{"FaucetGiveLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleFaucetGiveLog()(ctx, testLog(faucetGiveEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, encodeData("uint256", big.NewInt(100))), c)
+		}},
+		{"FaucetGiveInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleFaucetGiveInputData()(ctx, testInputData(faucetGiveToSig, false, testAlice), c)
+		}},
+		{"FaucetGimmeInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleFaucetGiveInputData()(ctx, testInputData(faucetGimmeSig, false), c)
+		}},
+		{"IndexAddLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleIndexAddLog(hc)(ctx, testLog(indexAddEvent, nil, encodeData("address", testBob)), c)
+		}},
+		{"IndexAddInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleIndexAddInputData()(ctx, testInputData(indexAddSig, false, testBob), c)
+		}},
+		{"IndexRemoveLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleIndexRemoveLog(hc)(ctx, testLog(indexRemoveEvent, nil, encodeData("address", testBob)), c)
+		}},
+		{"IndexRemoveInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleIndexRemoveInputData()(ctx, testInputData(indexRemoveSig, false, testBob), c)
+		}},
+		{"OwnershipLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleOwnershipLog()(ctx, testLog(ownershipEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, nil), c)
+		}},
+		{"OwnershipInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleOwnershipInputData()(ctx, testInputData(ownershipToSig, false, testBob), c)
+		}},
+		{"PoolDepositLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandlePoolDepositLog()(ctx, testLog(poolDepositEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, encodeData("uint256", big.NewInt(100))), c)
+		}},
+		{"PoolDepositInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandlePoolDepositInputData()(ctx, testInputData(poolDepositSig, false, testBob, big.NewInt(100)), c)
+		}},
+		{"PoolSwapLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandlePoolSwapLog()(ctx, testLog(poolSwapEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, encodeData("address,uint256,uint256,uint256", testContract, big.NewInt(100), big.NewInt(99), big.NewInt(1))), c)
+		}},
+		{"PoolSwapInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandlePoolSwapInputData()(ctx, testInputData(poolSwapSig, false, testBob, testContract, big.NewInt(100)), c)
+		}},
+		{"QuoterPriceLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleQuoterPriceUpdateLog()(ctx, testLog(quoterPriceEvent, nil, encodeData("address,uint256", testBob, big.NewInt(10_000))), c)
+		}},
+		{"QuoterPriceInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleQuoterPriceUpdateInputdata()(ctx, testInputData(quoterPriceToSig, false, testBob, big.NewInt(10_000)), c)
+		}},
+		{"QuoterUpdatedLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleQuoterUpdatedLog()(ctx, testLog(quoterUpdatedEvent, []common.Hash{addressTopic(testBob)}, nil), c)
+		}},
+		{"QuoterUpdatedInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleQuoterUpdatedInputData()(ctx, testInputData(quoterUpdatedSig, false, testBob), c)
+		}},
+		{"SealStateChangeLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleSealStateChangeLog()(ctx, testLog(sealEvent, []common.Hash{common.BigToHash(big.NewInt(1))}, encodeData("uint256", big.NewInt(3))), c)
+		}},
+		{"SealStateChangeInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleSealStateChangeInputData()(ctx, testInputData(sealToSig, false, big.NewInt(3)), c)
+		}},
+		{"TokenApproveLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenApproveLog(hc)(ctx, testLog(tokenApproveEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, encodeData("uint256", big.NewInt(100))), c)
+		}},
+		{"TokenApproveInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenApproveInputData(hc)(ctx, testInputData(tokenApproveToSig, false, testBob, big.NewInt(100)), c)
+		}},
+		{"TokenBurnLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenBurnLog()(ctx, testLog(tokenBurnEvent, []common.Hash{addressTopic(testAlice)}, encodeData("uint256", big.NewInt(100))), c)
+		}},
+		{"TokenBurnInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenBurnInputData()(ctx, testInputData(tokenBurnToSig, false, big.NewInt(100)), c)
+		}},
+		{"TokenMintLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenMintLog()(ctx, testLog(tokenMintEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, encodeData("uint256", big.NewInt(100))), c)
+		}},
+		{"TokenMintInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenMintInputData()(ctx, testInputData(tokenMintToSig, false, testBob, big.NewInt(100)), c)
+		}},
+		{"TokenTransferLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenTransferLog(hc)(ctx, testLog(tokenTransferEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, encodeData("uint256", big.NewInt(100))), c)
+		}},
+		{"TokenTransferFromLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenTransferFromLog(hc)(ctx, testLog(tokenTransferFromEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob), addressTopic(testContract)}, encodeData("uint256", big.NewInt(100))), c)
+		}},
+		{"TokenTransferInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenTransferInputData(hc)(ctx, testInputData(tokenTransferSig, false, testBob, big.NewInt(100)), c)
+		}},
+		{"TokenTransferFromInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenTransferInputData(hc)(ctx, testInputData(tokenTransferFromSig, false, testAlice, testBob, big.NewInt(100)), c)
+		}},
+		{"TokenPermitInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			idp := testInputData(tokenPermitSig, true, testAlice, testBob, big.NewInt(100), big.NewInt(1_000), uint8(27), common.Hash{}, common.Hash{})
+			idp.Logs = []*types.Log{testLog(tokenApproveEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, encodeData("uint256", big.NewInt(100))).Log}
+			return HandleTokenPermitInputData(hc)(ctx, idp, c)
+		}},
+		{"TokenPermitRevertedInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenPermitInputData(hc)(ctx, testInputData(tokenPermitSig, false, testAlice, testBob, big.NewInt(100), big.NewInt(1_000), uint8(27), common.Hash{}, common.Hash{}), c)
+		}},
+		{"NFTTransferLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenTransferLog(hc)(ctx, testLog(nftTransferEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob), common.BigToHash(big.NewInt(7))}, nil), c)
+		}},
+		{"NFTTransferInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleNFTTransferInputData(hc)(ctx, testInputData(nftSafeTransferFromSig, false, testAlice, testBob, big.NewInt(7)), c)
+		}},
+		{"NFTTransferFromInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenTransferInputData(hc)(ctx, testNFTInputData(tokenTransferFromSig, testAlice, testBob, big.NewInt(7)), c)
+		}},
+		{"NFTApproveLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenApproveLog(hc)(ctx, testLog(nftApproveEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob), common.BigToHash(big.NewInt(7))}, nil), c)
+		}},
+		{"NFTApproveInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleTokenApproveInputData(hc)(ctx, testNFTInputData(nftApproveSig, testBob, big.NewInt(7)), c)
+		}},
+		{"ApprovalForAllLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleApprovalForAllLog(hc)(ctx, testLog(approvalForAllEvent, []common.Hash{addressTopic(testAlice), addressTopic(testBob)}, encodeData("bool", true)), c)
+		}},
+		{"ApprovalForAllInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleApprovalForAllInputData(hc)(ctx, testInputData(setApprovalForAllSig, false, testBob, true), c)
+		}},
+		{"MultiTokenTransferSingleLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleMultiTokenTransferSingleLog(hc)(ctx, testLog(multiTokenTransferSingleEvent, []common.Hash{addressTopic(testAlice), addressTopic(testAlice), addressTopic(testBob)}, encodeData("uint256,uint256", big.NewInt(7), big.NewInt(2))), c)
+		}},
+		{"MultiTokenTransferBatchLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleMultiTokenTransferBatchLog(hc)(ctx, testLog(multiTokenTransferBatchEvent, []common.Hash{addressTopic(testAlice), addressTopic(testAlice), addressTopic(testBob)}, encodeData("uint256[],uint256[]", []*big.Int{big.NewInt(7)}, []*big.Int{big.NewInt(2)})), c)
+		}},
+		{"MultiTokenTransferInputData", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleMultiTokenTransferInputData(hc)(ctx, testInputData(multiTokenSafeBatchTransferFromSig, false, testAlice, testBob, []*big.Int{big.NewInt(7)}, []*big.Int{big.NewInt(2)}, []byte{}), c)
+		}},
+		{"UserOperationLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleUserOperationLog(hc)(ctx, testLog(userOperationEvent, []common.Hash{testTxHash, addressTopic(testAlice), addressTopic(common.Address{})}, encodeData("uint256,bool,uint256,uint256", big.NewInt(0), true, big.NewInt(100), big.NewInt(50_000))), c)
+		}},
+		{"AccountDeployedLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleAccountDeployedLog(hc)(ctx, testLog(accountDeployedEvent, []common.Hash{testTxHash, addressTopic(testAlice)}, encodeData("address,address", testBob, common.Address{})), c)
+		}},
+		{"UserOperationRevertReasonLog", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleUserOperationRevertReasonLog(hc)(ctx, testLog(userOperationRevertReasonEvent, []common.Hash{testTxHash, addressTopic(testAlice)}, encodeData("uint256,bytes", big.NewInt(0), []byte{0x01})), c)
+		}},
+		{"BlockProcessed", func(ctx context.Context, hc *HandlerContainer, c router.Callback) error {
+			return HandleBlockProcessed(ctx, types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), Time: 1}), 3, c)
+		}},
+	}
+}
+
+func testLog(ev *w3.Event, topics []common.Hash, data []byte) router.LogPayload {
+	return router.LogPayload{
+		Log: &types.Log{
+			Address:     testContract,
+			Topics:      append([]common.Hash{ev.Topic0}, topics...),
+			Data:        data,
+			BlockNumber: 1,
+			TxHash:      testTxHash,
+			Index:       3,
+		},
+		Timestamp: 1_700_000_000,
+		Sender:    testAlice.Hex(),
+	}
+}
+
+// testInputData uses block 0 so that handlers do not query historical chain state
+func testInputData(fn *w3.Func, success bool, args ...any) router.InputDataPayload {
+	return router.InputDataPayload{
+		From:            testAlice.Hex(),
+		InputData:       common.Bytes2Hex(mustEncodeArgs(fn, args...)),
+		ContractAddress: testContract.Hex(),
+		Timestamp:       1_700_000_000,
+		TxHash:          testTxHash.Hex(),
+		Success:         success,
+	}
+}
+
+func testNFTInputData(fn *w3.Func, args ...any) router.InputDataPayload {
+	idp := testInputData(fn, false, args...)
+	idp.ContractAddress = testNFT.Hex()
+
+	return idp
+}
+
+func encodeData(argTypes string, args ...any) []byte {
+	return mustEncodeArgs(w3.MustNewFunc("data("+argTypes+")", ""), args...)[4:]
+}
+
+func mustEncodeArgs(fn *w3.Func, args ...any) []byte {
+	input, err := fn.EncodeArgs(args...)
+	if err != nil {
+		panic(err)
+	}
+
+	return input
+}
+
+func addressTopic(address common.Address) common.Hash {
+	return common.BytesToHash(address.Bytes())
+}
@@ -22,7 +22,7 @@ func HandleIndexAddLog(hc *HandlerContainer) router.LogHandlerFunc {
 		var address common.Address
 
 		if err := indexAddEvent.DecodeArgs(lp.Log, &address); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		indexAddEvent := event.Event{
@@ -33,8 +33,8 @@ func HandleIndexAddLog(hc *HandlerContainer) router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          indexAddEventName,
-			Payload: map[string]any{
-				"address": address.Hex(),
+			Payload: &event.IndexAddPayload{
+				Address: address.Hex(),
 			},
 		}
 
@@ -61,24 +61,24 @@ func HandleIndexAddInputData() router.InputDataHandlerFunc {
 		case "0a3b0a4f":
 			var address common.Address
 
-			indexAddEvent.Payload = map[string]any{
-				"address": address.Hex(),
+			if err := indexAddSig.DecodeArgs(w3.B(idp.InputData), &address); err != nil {
+				return &router.DecodeError{Err: err}
 			}
 
-			if err := indexAddSig.DecodeArgs(w3.B(idp.InputData), &address); err != nil {
-				return err
+			indexAddEvent.Payload = &event.IndexAddPayload{
+				Address: address.Hex(),
 			}
 
 			return c(ctx, indexAddEvent)
 		case "4420e486":
 			var address common.Address
 
-			indexAddEvent.Payload = map[string]any{
-				"address": address.Hex(),
+			if err := indexRegisterSig.DecodeArgs(w3.B(idp.InputData), &address); err != nil {
+				return &router.DecodeError{Err: err}
 			}
 
-			if err := indexRegisterSig.DecodeArgs(w3.B(idp.InputData), &address); err != nil {
-				return err
+			indexAddEvent.Payload = &event.IndexAddPayload{
+				Address: address.Hex(),
 			}
 
 			return c(ctx, indexAddEvent)
@@ -21,7 +21,7 @@ func HandleIndexRemoveLog(hc *HandlerContainer) router.LogHandlerFunc {
 		var address common.Address
 
 		if err := indexRemoveEvent.DecodeArgs(lp.Log, &address); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		indexRemoveEvent := event.Event{
@@ -32,8 +32,8 @@ func HandleIndexRemoveLog(hc *HandlerContainer) router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          indexRemoveEventName,
-			Payload: map[string]any{
-				"address": address.Hex(),
+			Payload: &event.IndexRemovePayload{
+				Address: address.Hex(),
 			},
 		}
 
@@ -50,7 +50,7 @@ func HandleIndexRemoveInputData() router.InputDataHandlerFunc {
 		var address common.Address
 
 		if err := indexRemoveSig.DecodeArgs(w3.B(idp.InputData), &address); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		indexRemoveEvent := event.Event{
@@ -60,8 +60,8 @@ func HandleIndexRemoveInputData() router.InputDataHandlerFunc {
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          indexRemoveEventName,
-			Payload: map[string]any{
-				"address": address.Hex(),
+			Payload: &event.IndexRemovePayload{
+				Address: address.Hex(),
 			},
 		}
 
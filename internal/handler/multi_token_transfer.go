@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+	"github.com/lmittmann/w3"
+)
+
+const multiTokenTransferEventName = "MULTI_TOKEN_TRANSFER"
+
+var (
+	multiTokenTransferSingleEvent = w3.MustNewEvent("TransferSingle(address indexed _operator, address indexed _from, address indexed _to, uint256 _id, uint256 _value)")
+	multiTokenTransferBatchEvent  = w3.MustNewEvent("TransferBatch(address indexed _operator, address indexed _from, address indexed _to, uint256[] _ids, uint256[] _values)")
+
+	multiTokenSafeTransferFromSig      = w3.MustNewFunc("safeTransferFrom(address, address, uint256, uint256, bytes)", "")
+	multiTokenSafeBatchTransferFromSig = w3.MustNewFunc("safeBatchTransferFrom(address, address, uint256[], uint256[], bytes)", "")
+)
+
+func HandleMultiTokenTransferSingleLog(hc *HandlerContainer) router.LogHandlerFunc {
+	return func(ctx context.Context, lp router.LogPayload, c router.Callback) error {
+		var (
+			operator common.Address
+			from     common.Address
+			to       common.Address
+			id       big.Int
+			value    big.Int
+		)
+
+		if err := multiTokenTransferSingleEvent.DecodeArgs(lp.Log, &operator, &from, &to, &id, &value); err != nil {
+			return &router.DecodeError{Err: err}
+		}
+
+		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), from.Hex(), to.Hex(), lp.Sender)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+
+		multiTokenTransferEvent := event.Event{
+			Index:           lp.Log.Index,
+			Block:           lp.Log.BlockNumber,
+			ContractAddress: lp.Log.Address.Hex(),
+			Success:         true,
+			Timestamp:       lp.Timestamp,
+			TxHash:          lp.Log.TxHash.Hex(),
+			TxType:          multiTokenTransferEventName,
+			Payload: &event.MultiTokenTransferPayload{
+				Operator: operator.Hex(),
+				From:     from.Hex(),
+				To:       to.Hex(),
+				TokenIDs: []string{id.String()},
+				Values:   []string{value.String()},
+			},
+		}
+
+		return c(ctx, multiTokenTransferEvent)
+	}
+}
+
+func HandleMultiTokenTransferBatchLog(hc *HandlerContainer) router.LogHandlerFunc {
+	return func(ctx context.Context, lp router.LogPayload, c router.Callback) error {
+		var (
+			operator common.Address
+			from     common.Address
+			to       common.Address
+			ids      []*big.Int
+			values   []*big.Int
+		)
+
+		if err := multiTokenTransferBatchEvent.DecodeArgs(lp.Log, &operator, &from, &to, &ids, &values); err != nil {
+			return &router.DecodeError{Err: err}
+		}
+
+		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), from.Hex(), to.Hex(), lp.Sender)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+
+		multiTokenTransferEvent := event.Event{
+			Index:           lp.Log.Index,
+			Block:           lp.Log.BlockNumber,
+			ContractAddress: lp.Log.Address.Hex(),
+			Success:         true,
+			Timestamp:       lp.Timestamp,
+			TxHash:          lp.Log.TxHash.Hex(),
+			TxType:          multiTokenTransferEventName,
+			Payload: &event.MultiTokenTransferPayload{
+				Operator: operator.Hex(),
+				From:     from.Hex(),
+				To:       to.Hex(),
+				TokenIDs: bigIntsToStrings(ids),
+				Values:   bigIntsToStrings(values),
+			},
+		}
+
+		return c(ctx, multiTokenTransferEvent)
+	}
+}
+
+func HandleMultiTokenTransferInputData(hc *HandlerContainer) router.InputDataHandlerFunc {
+	return func(ctx context.Context, idp router.InputDataPayload, c router.Callback) error {
+		var (
+			from   common.Address
+			to     common.Address
+			ids    []*big.Int
+			values []*big.Int
+			data   []byte
+		)
+
+		switch idp.InputData[:8] {
+		case "f242432a":
+			var id, value big.Int
+
+			if err := multiTokenSafeTransferFromSig.DecodeArgs(w3.B(idp.InputData), &from, &to, &id, &value, &data); err != nil {
+				return &router.DecodeError{Err: err}
+			}
+			ids, values = []*big.Int{&id}, []*big.Int{&value}
+		case "2eb2c2d6":
+			if err := multiTokenSafeBatchTransferFromSig.DecodeArgs(w3.B(idp.InputData), &from, &to, &ids, &values, &data); err != nil {
+				return &router.DecodeError{Err: err}
+			}
+		default:
+			return nil
+		}
+
+		proceed, err := hc.checkWithinNetwork(ctx, idp.ContractAddress, from.Hex(), to.Hex())
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+
+		multiTokenTransferEvent := event.Event{
+			Block:           idp.Block,
+			ContractAddress: idp.ContractAddress,
+			Success:         false,
+			Timestamp:       idp.Timestamp,
+			TxHash:          idp.TxHash,
+			TxType:          multiTokenTransferEventName,
+			Payload: &event.MultiTokenTransferPayload{
+				Operator: idp.From,
+				From:     from.Hex(),
+				To:       to.Hex(),
+				TokenIDs: bigIntsToStrings(ids),
+				Values:   bigIntsToStrings(values),
+			},
+		}
+
+		return c(ctx, multiTokenTransferEvent)
+	}
+}
+
+func bigIntsToStrings(values []*big.Int) []string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = v.String()
+	}
+
+	return strs
+}
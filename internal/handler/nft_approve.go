@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+)
+
+const (
+	nftApproveEventName     = "NFT_APPROVE"
+	approvalForAllEventName = "APPROVAL_FOR_ALL"
+)
+
+var (
+	nftApproveEvent     = w3.MustNewEvent("Approval(address indexed _owner, address indexed _approved, uint256 indexed _tokenId)")
+	approvalForAllEvent = w3.MustNewEvent("ApprovalForAll(address indexed _owner, address indexed _operator, bool _approved)")
+
+	nftApproveSig        = w3.MustNewFunc("approve(address, uint256)", "")
+	setApprovalForAllSig = w3.MustNewFunc("setApprovalForAll(address, bool)", "")
+
+	supportsInterfaceGetter = w3.MustNewFunc("supportsInterface(bytes4)", "bool")
+	erc721InterfaceID       = [4]byte{0x80, 0xac, 0x58, 0xcd}
+)
+
+// ERC-721 shares the Approval topic with ERC-20, the token id is the third indexed topic
+func handleNFTApproveLog(ctx context.Context, hc *HandlerContainer, lp router.LogPayload, c router.Callback) error {
+	var (
+		owner    common.Address
+		approved common.Address
+		tokenID  big.Int
+	)
+
+	if err := nftApproveEvent.DecodeArgs(lp.Log, &owner, &approved, &tokenID); err != nil {
+		return &router.DecodeError{Err: err}
+	}
+
+	proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), owner.Hex(), approved.Hex(), lp.Sender)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	nftApproveEvent := event.Event{
+		Index:           lp.Log.Index,
+		Block:           lp.Log.BlockNumber,
+		ContractAddress: lp.Log.Address.Hex(),
+		Success:         true,
+		Timestamp:       lp.Timestamp,
+		TxHash:          lp.Log.TxHash.Hex(),
+		TxType:          nftApproveEventName,
+		Payload: &event.NFTApprovePayload{
+			Owner:    owner.Hex(),
+			Approved: approved.Hex(),
+			TokenID:  tokenID.String(),
+		},
+	}
+
+	return c(ctx, nftApproveEvent)
+}
+
+// approve(address, uint256) shares its selector with ERC-20, the caller decides from isERC721
+func handleNFTApproveInputData(ctx context.Context, hc *HandlerContainer, idp router.InputDataPayload, c router.Callback) error {
+	var (
+		approved common.Address
+		tokenID  big.Int
+	)
+
+	if err := nftApproveSig.DecodeArgs(w3.B(idp.InputData), &approved, &tokenID); err != nil {
+		return &router.DecodeError{Err: err}
+	}
+
+	proceed, err := hc.checkWithinNetwork(ctx, idp.ContractAddress, idp.From, approved.Hex())
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	nftApproveEvent := event.Event{
+		Block:           idp.Block,
+		ContractAddress: idp.ContractAddress,
+		Success:         false,
+		Timestamp:       idp.Timestamp,
+		TxHash:          idp.TxHash,
+		TxType:          nftApproveEventName,
+		Payload: &event.NFTApprovePayload{
+			Owner:    idp.From,
+			Approved: approved.Hex(),
+			TokenID:  tokenID.String(),
+		},
+	}
+
+	return c(ctx, nftApproveEvent)
+}
+
+func HandleApprovalForAllLog(hc *HandlerContainer) router.LogHandlerFunc {
+	return func(ctx context.Context, lp router.LogPayload, c router.Callback) error {
+		var (
+			owner    common.Address
+			operator common.Address
+			approved bool
+		)
+
+		if err := approvalForAllEvent.DecodeArgs(lp.Log, &owner, &operator, &approved); err != nil {
+			return &router.DecodeError{Err: err}
+		}
+
+		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), owner.Hex(), operator.Hex(), lp.Sender)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+
+		approvalForAllEvent := event.Event{
+			Index:           lp.Log.Index,
+			Block:           lp.Log.BlockNumber,
+			ContractAddress: lp.Log.Address.Hex(),
+			Success:         true,
+			Timestamp:       lp.Timestamp,
+			TxHash:          lp.Log.TxHash.Hex(),
+			TxType:          approvalForAllEventName,
+			Payload: &event.ApprovalForAllPayload{
+				Owner:    owner.Hex(),
+				Operator: operator.Hex(),
+				Approved: approved,
+			},
+		}
+
+		return c(ctx, approvalForAllEvent)
+	}
+}
+
+func HandleApprovalForAllInputData(hc *HandlerContainer) router.InputDataHandlerFunc {
+	return func(ctx context.Context, idp router.InputDataPayload, c router.Callback) error {
+		var (
+			operator common.Address
+			approved bool
+		)
+
+		if err := setApprovalForAllSig.DecodeArgs(w3.B(idp.InputData), &operator, &approved); err != nil {
+			return &router.DecodeError{Err: err}
+		}
+
+		proceed, err := hc.checkWithinNetwork(ctx, idp.ContractAddress, idp.From, operator.Hex())
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+
+		approvalForAllEvent := event.Event{
+			Block:           idp.Block,
+			ContractAddress: idp.ContractAddress,
+			Success:         false,
+			Timestamp:       idp.Timestamp,
+			TxHash:          idp.TxHash,
+			TxType:          approvalForAllEventName,
+			Payload: &event.ApprovalForAllPayload{
+				Owner:    idp.From,
+				Operator: operator.Hex(),
+				Approved: approved,
+			},
+		}
+
+		return c(ctx, approvalForAllEvent)
+	}
+}
+
+// isERC721 tells ERC-721 and ERC-20 calls with the same selector apart through ERC-165,
+// contracts without supportsInterface are treated as ERC-20
+func (hc *HandlerContainer) isERC721(ctx context.Context, contract string) (bool, error) {
+	if supported, ok := hc.erc721.Load(contract); ok {
+		return supported, nil
+	}
+
+	var supported bool
+
+	err := hc.chain.Provider().Client.CallCtx(
+		ctx,
+		eth.CallFunc(common.HexToAddress(contract), supportsInterfaceGetter, erc721InterfaceID).Returns(&supported),
+	)
+	if err != nil {
+		if !errors.Is(err, w3.CallErrors{}) {
+			return false, err
+		}
+		supported = false
+	}
+	hc.erc721.Store(contract, supported)
+
+	return supported, nil
+}
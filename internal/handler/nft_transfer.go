@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+	"github.com/lmittmann/w3"
+)
+
+const nftTransferEventName = "NFT_TRANSFER"
+
+var (
+	nftTransferEvent = w3.MustNewEvent("Transfer(address indexed _from, address indexed _to, uint256 indexed _tokenId)")
+
+	nftSafeTransferFromSig         = w3.MustNewFunc("safeTransferFrom(address, address, uint256)", "")
+	nftSafeTransferFromWithDataSig = w3.MustNewFunc("safeTransferFrom(address, address, uint256, bytes)", "")
+)
+
+// ERC-721 shares the Transfer topic with ERC-20, the token id is the third indexed topic
+func handleNFTTransferLog(ctx context.Context, hc *HandlerContainer, lp router.LogPayload, c router.Callback) error {
+	var (
+		from    common.Address
+		to      common.Address
+		tokenID big.Int
+	)
+
+	if err := nftTransferEvent.DecodeArgs(lp.Log, &from, &to, &tokenID); err != nil {
+		return &router.DecodeError{Err: err}
+	}
+
+	proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), from.Hex(), to.Hex(), lp.Sender)
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return nil
+	}
+
+	nftTransferEvent := event.Event{
+		Index:           lp.Log.Index,
+		Block:           lp.Log.BlockNumber,
+		ContractAddress: lp.Log.Address.Hex(),
+		Success:         true,
+		Timestamp:       lp.Timestamp,
+		TxHash:          lp.Log.TxHash.Hex(),
+		TxType:          nftTransferEventName,
+		Payload: &event.NFTTransferPayload{
+			From:    from.Hex(),
+			To:      to.Hex(),
+			TokenID: tokenID.String(),
+		},
+	}
+
+	return c(ctx, nftTransferEvent)
+}
+
+func HandleNFTTransferInputData(hc *HandlerContainer) router.InputDataHandlerFunc {
+	return func(ctx context.Context, idp router.InputDataPayload, c router.Callback) error {
+		var (
+			from    common.Address
+			to      common.Address
+			tokenID big.Int
+			data    []byte
+		)
+
+		switch idp.InputData[:8] {
+		case "23b872dd":
+			if err := tokenTransferFromSig.DecodeArgs(w3.B(idp.InputData), &from, &to, &tokenID); err != nil {
+				return &router.DecodeError{Err: err}
+			}
+		case "42842e0e":
+			if err := nftSafeTransferFromSig.DecodeArgs(w3.B(idp.InputData), &from, &to, &tokenID); err != nil {
+				return &router.DecodeError{Err: err}
+			}
+		case "b88d4fde":
+			if err := nftSafeTransferFromWithDataSig.DecodeArgs(w3.B(idp.InputData), &from, &to, &tokenID, &data); err != nil {
+				return &router.DecodeError{Err: err}
+			}
+		default:
+			return nil
+		}
+
+		proceed, err := hc.checkWithinNetwork(ctx, idp.ContractAddress, from.Hex(), to.Hex())
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+
+		nftTransferEvent := event.Event{
+			Block:           idp.Block,
+			ContractAddress: idp.ContractAddress,
+			Success:         false,
+			Timestamp:       idp.Timestamp,
+			TxHash:          idp.TxHash,
+			TxType:          nftTransferEventName,
+			Payload: &event.NFTTransferPayload{
+				From:    from.Hex(),
+				To:      to.Hex(),
+				TokenID: tokenID.String(),
+			},
+		}
+
+		return c(ctx, nftTransferEvent)
+	}
+}
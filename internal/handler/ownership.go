@@ -24,7 +24,7 @@ func HandleOwnershipLog() router.LogHandlerFunc {
 		)
 
 		if err := ownershipEvent.DecodeArgs(lp.Log, &previousOwner, &newOwner); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		ownershipEvent := event.Event{
@@ -35,9 +35,9 @@ func HandleOwnershipLog() router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          ownershipEventName,
-			Payload: map[string]any{
-				"previousOwner": previousOwner.Hex(),
-				"newOwner":      newOwner.Hex(),
+			Payload: &event.OwnershipTransferredPayload{
+				PreviousOwner: previousOwner.Hex(),
+				NewOwner:      newOwner.Hex(),
 			},
 		}
 
@@ -50,7 +50,7 @@ func HandleOwnershipInputData() router.InputDataHandlerFunc {
 		var newOwner common.Address
 
 		if err := ownershipToSig.DecodeArgs(w3.B(idp.InputData), &newOwner); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		ownershipEvent := event.Event{
@@ -60,9 +60,9 @@ func HandleOwnershipInputData() router.InputDataHandlerFunc {
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          ownershipEventName,
-			Payload: map[string]any{
-				"previousOwner": idp.From,
-				"newOwner":      newOwner.Hex(),
+			Payload: &event.OwnershipTransferredPayload{
+				PreviousOwner: idp.From,
+				NewOwner:      newOwner.Hex(),
 			},
 		}
 
@@ -31,7 +31,7 @@ func HandlePoolDepositLog() router.LogHandlerFunc {
 			&tokenIn,
 			&amountIn,
 		); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		poolDepositEvent := event.Event{
@@ -42,10 +42,10 @@ func HandlePoolDepositLog() router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          poolDepositEventName,
-			Payload: map[string]any{
-				"initiator": initiator.Hex(),
-				"tokenIn":   tokenIn.Hex(),
-				"amountIn":  amountIn.String(),
+			Payload: &event.PoolDepositPayload{
+				Initiator: initiator.Hex(),
+				TokenIn:   tokenIn.Hex(),
+				AmountIn:  amountIn.String(),
 			},
 		}
 
@@ -61,7 +61,7 @@ func HandlePoolDepositInputData() router.InputDataHandlerFunc {
 		)
 
 		if err := poolDepositSig.DecodeArgs(w3.B(idp.InputData), &tokenIn, &amountIn); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		poolDepositEvent := event.Event{
@@ -71,10 +71,10 @@ func HandlePoolDepositInputData() router.InputDataHandlerFunc {
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          poolDepositEventName,
-			Payload: map[string]any{
-				"initiator": idp.From,
-				"tokenIn":   tokenIn.Hex(),
-				"amountIn":  amountIn.String(),
+			Payload: &event.PoolDepositPayload{
+				Initiator: idp.From,
+				TokenIn:   tokenIn.Hex(),
+				AmountIn:  amountIn.String(),
 			},
 		}
 
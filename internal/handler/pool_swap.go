@@ -37,7 +37,7 @@ func HandlePoolSwapLog() router.LogHandlerFunc {
 			&amountOut,
 			&fee,
 		); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		poolSwapEvent := event.Event{
@@ -48,13 +48,13 @@ func HandlePoolSwapLog() router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          poolSwapEventName,
-			Payload: map[string]any{
-				"initiator": initiator.Hex(),
-				"tokenIn":   tokenIn.Hex(),
-				"tokenOut":  tokenOut.Hex(),
-				"amountIn":  amountIn.String(),
-				"amountOut": amountOut.String(),
-				"fee":       fee.String(),
+			Payload: &event.PoolSwapPayload{
+				Initiator: initiator.Hex(),
+				TokenIn:   tokenIn.Hex(),
+				TokenOut:  tokenOut.Hex(),
+				AmountIn:  amountIn.String(),
+				AmountOut: amountOut.String(),
+				Fee:       fee.String(),
 			},
 		}
 
@@ -71,7 +71,7 @@ func HandlePoolSwapInputData() router.InputDataHandlerFunc {
 		)
 
 		if err := poolSwapSig.DecodeArgs(w3.B(idp.InputData), &tokenOut, &tokenIn, &amountIn); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		poolSwapEvent := event.Event{
@@ -81,13 +81,13 @@ func HandlePoolSwapInputData() router.InputDataHandlerFunc {
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          poolSwapEventName,
-			Payload: map[string]any{
-				"initiator": idp.From,
-				"tokenIn":   tokenIn.Hex(),
-				"tokenOut":  tokenOut.Hex(),
-				"amountIn":  amountIn.String(),
-				"amountOut": "0",
-				"fee":       "0",
+			Payload: &event.PoolSwapPayload{
+				Initiator: idp.From,
+				TokenIn:   tokenIn.Hex(),
+				TokenOut:  tokenOut.Hex(),
+				AmountIn:  amountIn.String(),
+				AmountOut: "0",
+				Fee:       "0",
 			},
 		}
 
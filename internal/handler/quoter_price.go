@@ -25,7 +25,7 @@ func HandleQuoterPriceUpdateLog() router.LogHandlerFunc {
 		)
 
 		if err := quoterPriceEvent.DecodeArgs(lp.Log, &token, &exchangeRate); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		quoterPriceEvent := event.Event{
@@ -36,9 +36,9 @@ func HandleQuoterPriceUpdateLog() router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          quoterPriceEventName,
-			Payload: map[string]any{
-				"token":        token.Hex(),
-				"exchangeRate": exchangeRate.String(),
+			Payload: &event.QuoterPriceIndexUpdatedPayload{
+				Token:        token.Hex(),
+				ExchangeRate: exchangeRate.String(),
 			},
 		}
 
@@ -54,7 +54,7 @@ func HandleQuoterPriceUpdateInputdata() router.InputDataHandlerFunc {
 		)
 
 		if err := quoterPriceToSig.DecodeArgs(w3.B(idp.InputData), &token, &exchangeRate); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		quoterPriceEvent := event.Event{
@@ -64,9 +64,9 @@ func HandleQuoterPriceUpdateInputdata() router.InputDataHandlerFunc {
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          quoterPriceEventName,
-			Payload: map[string]any{
-				"token":        token.Hex(),
-				"exchangeRate": exchangeRate.String(),
+			Payload: &event.QuoterPriceIndexUpdatedPayload{
+				Token:        token.Hex(),
+				ExchangeRate: exchangeRate.String(),
 			},
 		}
 
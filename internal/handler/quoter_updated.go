@@ -21,7 +21,7 @@ func HandleQuoterUpdatedLog() router.LogHandlerFunc {
 		var newQuoter common.Address
 
 		if err := quoterUpdatedEvent.DecodeArgs(lp.Log, &newQuoter); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		quoterUpdatedEvent := event.Event{
@@ -32,8 +32,8 @@ func HandleQuoterUpdatedLog() router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          quoterUpdatedEventName,
-			Payload: map[string]any{
-				"newQuoter": newQuoter.Hex(),
+			Payload: &event.QuoterUpdatedPayload{
+				NewQuoter: newQuoter.Hex(),
 			},
 		}
 
@@ -46,7 +46,7 @@ func HandleQuoterUpdatedInputData() router.InputDataHandlerFunc {
 		var newQuoter common.Address
 
 		if err := quoterUpdatedSig.DecodeArgs(w3.B(idp.InputData), &newQuoter); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		quoterUpdatedEvent := event.Event{
@@ -56,8 +56,8 @@ func HandleQuoterUpdatedInputData() router.InputDataHandlerFunc {
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          quoterUpdatedEventName,
-			Payload: map[string]any{
-				"newQuoter": newQuoter.Hex(),
+			Payload: &event.QuoterUpdatedPayload{
+				NewQuoter: newQuoter.Hex(),
 			},
 		}
 
@@ -24,7 +24,7 @@ func HandleSealStateChangeLog() router.LogHandlerFunc {
 		)
 
 		if err := sealEvent.DecodeArgs(lp.Log, &final, &sealState); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		sealEvent := event.Event{
@@ -35,9 +35,9 @@ func HandleSealStateChangeLog() router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          sealEventName,
-			Payload: map[string]any{
-				"final":     final,
-				"sealState": sealState.String(),
+			Payload: &event.SealStateChangePayload{
+				Final:     &final,
+				SealState: sealState.String(),
 			},
 		}
 
@@ -50,7 +50,7 @@ func HandleSealStateChangeInputData() router.InputDataHandlerFunc {
 		var sealState big.Int
 
 		if err := sealToSig.DecodeArgs(w3.B(idp.InputData), &sealState); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		sealEvent := event.Event{
@@ -60,8 +60,8 @@ func HandleSealStateChangeInputData() router.InputDataHandlerFunc {
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          sealEventName,
-			Payload: map[string]any{
-				"sealState": sealState.String(),
+			Payload: &event.SealStateChangePayload{
+				SealState: sealState.String(),
 			},
 		}
 
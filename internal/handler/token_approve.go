@@ -19,6 +19,10 @@ var (
 
 func HandleTokenApproveLog(hc *HandlerContainer) router.LogHandlerFunc {
 	return func(ctx context.Context, lp router.LogPayload, c router.Callback) error {
+		if len(lp.Log.Topics) == 4 {
+			return handleNFTApproveLog(ctx, hc, lp, c)
+		}
+
 		var (
 			owner   common.Address
 			spender common.Address
@@ -26,10 +30,10 @@ func HandleTokenApproveLog(hc *HandlerContainer) router.LogHandlerFunc {
 		)
 
 		if err := tokenApproveEvent.DecodeArgs(lp.Log, &owner, &spender, &value); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
-		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), owner.Hex(), spender.Hex())
+		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), owner.Hex(), spender.Hex(), lp.Sender)
 		if err != nil {
 			return err
 		}
@@ -45,10 +49,10 @@ func HandleTokenApproveLog(hc *HandlerContainer) router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          approveEventName,
-			Payload: map[string]any{
-				"owner":   owner.Hex(),
-				"spender": spender.Hex(),
-				"value":   value.String(),
+			Payload: &event.TokenApprovePayload{
+				Owner:   owner.Hex(),
+				Spender: spender.Hex(),
+				Value:   value.String(),
 			},
 		}
 
@@ -58,13 +62,21 @@ func HandleTokenApproveLog(hc *HandlerContainer) router.LogHandlerFunc {
 
 func HandleTokenApproveInputData(hc *HandlerContainer) router.InputDataHandlerFunc {
 	return func(ctx context.Context, idp router.InputDataPayload, c router.Callback) error {
+		erc721, err := hc.isERC721(ctx, idp.ContractAddress)
+		if err != nil {
+			return err
+		}
+		if erc721 {
+			return handleNFTApproveInputData(ctx, hc, idp, c)
+		}
+
 		var (
 			spender common.Address
 			value   big.Int
 		)
 
 		if err := tokenApproveToSig.DecodeArgs(w3.B(idp.InputData), &spender, &value); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		proceed, err := hc.checkWithinNetwork(ctx, idp.ContractAddress, idp.From, spender.Hex())
@@ -82,10 +94,10 @@ func HandleTokenApproveInputData(hc *HandlerContainer) router.InputDataHandlerFu
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          approveEventName,
-			Payload: map[string]any{
-				"owner":   idp.From,
-				"spender": spender.Hex(),
-				"value":   value.String(),
+			Payload: &event.TokenApprovePayload{
+				Owner:   idp.From,
+				Spender: spender.Hex(),
+				Value:   value.String(),
 			},
 		}
 
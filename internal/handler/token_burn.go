@@ -25,7 +25,7 @@ func HandleTokenBurnLog() router.LogHandlerFunc {
 		)
 
 		if err := tokenBurnEvent.DecodeArgs(lp.Log, &tokenBurner, &value); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		tokenBurnEvent := event.Event{
@@ -36,9 +36,9 @@ func HandleTokenBurnLog() router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          burnEventName,
-			Payload: map[string]any{
-				"tokenBurner": tokenBurner.Hex(),
-				"value":       value.String(),
+			Payload: &event.TokenBurnPayload{
+				TokenBurner: tokenBurner.Hex(),
+				Value:       value.String(),
 			},
 		}
 
@@ -51,7 +51,7 @@ func HandleTokenBurnInputData() router.InputDataHandlerFunc {
 		var value big.Int
 
 		if err := tokenBurnToSig.DecodeArgs(w3.B(idp.InputData), &value); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		tokenBurnEvent := event.Event{
@@ -61,9 +61,9 @@ func HandleTokenBurnInputData() router.InputDataHandlerFunc {
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          burnEventName,
-			Payload: map[string]any{
-				"tokenBurner": idp.From,
-				"value":       value.String(),
+			Payload: &event.TokenBurnPayload{
+				TokenBurner: idp.From,
+				Value:       value.String(),
 			},
 		}
 
@@ -26,7 +26,7 @@ func HandleTokenMintLog() router.LogHandlerFunc {
 		)
 
 		if err := tokenMintEvent.DecodeArgs(lp.Log, &tokenMinter, &to, &value); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		tokenMintEvent := event.Event{
@@ -37,10 +37,10 @@ func HandleTokenMintLog() router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          mintEventName,
-			Payload: map[string]any{
-				"tokenMinter": tokenMinter.Hex(),
-				"to":          to.Hex(),
-				"value":       value.String(),
+			Payload: &event.TokenMintPayload{
+				TokenMinter: tokenMinter.Hex(),
+				To:          to.Hex(),
+				Value:       value.String(),
 			},
 		}
 
@@ -56,7 +56,7 @@ func HandleTokenMintInputData() router.InputDataHandlerFunc {
 		)
 
 		if err := tokenMintToSig.DecodeArgs(w3.B(idp.InputData), &to, &value); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
 		tokenMintEvent := event.Event{
@@ -66,10 +66,10 @@ func HandleTokenMintInputData() router.InputDataHandlerFunc {
 			Timestamp:       idp.Timestamp,
 			TxHash:          idp.TxHash,
 			TxType:          mintEventName,
-			Payload: map[string]any{
-				"tokenMinter": idp.From,
-				"to":          to.Hex(),
-				"value":       value.String(),
+			Payload: &event.TokenMintPayload{
+				TokenMinter: idp.From,
+				To:          to.Hex(),
+				Value:       value.String(),
 			},
 		}
 
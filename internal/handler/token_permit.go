@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+)
+
+const permitEventName = "TOKEN_PERMIT"
+
+var (
+	tokenPermitSig    = w3.MustNewFunc("permit(address, address, uint256, uint256, uint8, bytes32, bytes32)", "")
+	tokenNoncesGetter = w3.MustNewFunc("nonces(address)", "uint256")
+)
+
+func HandleTokenPermitInputData(hc *HandlerContainer) router.InputDataHandlerFunc {
+	return func(ctx context.Context, idp router.InputDataPayload, c router.Callback) error {
+		var (
+			owner    common.Address
+			spender  common.Address
+			value    big.Int
+			deadline big.Int
+			v        uint8
+			r        [32]byte
+			s        [32]byte
+		)
+
+		if err := tokenPermitSig.DecodeArgs(w3.B(idp.InputData), &owner, &spender, &value, &deadline, &v, &r, &s); err != nil {
+			return &router.DecodeError{Err: err}
+		}
+
+		proceed, err := hc.checkWithinNetwork(ctx, idp.ContractAddress, owner.Hex(), spender.Hex())
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+
+		nonce, err := hc.permitNonce(ctx, idp.ContractAddress, owner, idp.Block)
+		if err != nil {
+			return err
+		}
+
+		payload := &event.TokenPermitPayload{
+			Owner:    owner.Hex(),
+			Spender:  spender.Hex(),
+			Value:    value.String(),
+			Deadline: deadline.String(),
+			Nonce:    nonce,
+		}
+
+		tokenPermitEvent := event.Event{
+			Block:           idp.Block,
+			ContractAddress: idp.ContractAddress,
+			Success:         idp.Success,
+			Timestamp:       idp.Timestamp,
+			TxHash:          idp.TxHash,
+			TxType:          permitEventName,
+			Payload:         payload,
+		}
+
+		if idp.Success {
+			approvalLog, ok := findApprovalLog(idp, owner, spender)
+			if ok {
+				payload.ApprovalLogIndex = &approvalLog
+			}
+		}
+
+		return c(ctx, tokenPermitEvent)
+	}
+}
+
+// permitNonce returns the owner's nonce before the permit was included, an empty nonce means the token does not expose it
+func (hc *HandlerContainer) permitNonce(ctx context.Context, token string, owner common.Address, block uint64) (string, error) {
+	if block == 0 {
+		return "", nil
+	}
+
+	var nonce big.Int
+
+	err := hc.chain.Provider().Client.CallCtx(
+		ctx,
+		eth.CallFunc(common.HexToAddress(token), tokenNoncesGetter, owner).AtBlock(new(big.Int).SetUint64(block-1)).Returns(&nonce),
+	)
+	if err != nil {
+		if errors.Is(err, w3.CallErrors{}) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return nonce.String(), nil
+}
+
+func findApprovalLog(idp router.InputDataPayload, owner common.Address, spender common.Address) (uint, bool) {
+	for _, log := range idp.Logs {
+		if log.Address.Hex() != idp.ContractAddress || len(log.Topics) != 3 || log.Topics[0] != tokenApproveEvent.Topic0 {
+			continue
+		}
+
+		if common.BytesToAddress(log.Topics[1].Bytes()) == owner && common.BytesToAddress(log.Topics[2].Bytes()) == spender {
+			return log.Index, true
+		}
+	}
+
+	return 0, false
+}
@@ -22,6 +22,10 @@ var (
 
 func HandleTokenTransferLog(hc *HandlerContainer) router.LogHandlerFunc {
 	return func(ctx context.Context, lp router.LogPayload, c router.Callback) error {
+		if len(lp.Log.Topics) == 4 {
+			return handleNFTTransferLog(ctx, hc, lp, c)
+		}
+
 		var (
 			from  common.Address
 			to    common.Address
@@ -29,10 +33,10 @@ func HandleTokenTransferLog(hc *HandlerContainer) router.LogHandlerFunc {
 		)
 
 		if err := tokenTransferEvent.DecodeArgs(lp.Log, &from, &to, &value); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
-		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), from.Hex(), to.Hex())
+		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), from.Hex(), to.Hex(), lp.Sender)
 		if err != nil {
 			return err
 		}
@@ -48,10 +52,10 @@ func HandleTokenTransferLog(hc *HandlerContainer) router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          transferEventName,
-			Payload: map[string]any{
-				"from":  from.Hex(),
-				"to":    to.Hex(),
-				"value": value.String(),
+			Payload: &event.TokenTransferPayload{
+				From:  from.Hex(),
+				To:    to.Hex(),
+				Value: value.String(),
 			},
 		}
 
@@ -78,7 +82,7 @@ func HandleTokenTransferInputData(hc *HandlerContainer) router.InputDataHandlerF
 			)
 
 			if err := tokenTransferSig.DecodeArgs(w3.B(idp.InputData), &to, &value); err != nil {
-				return err
+				return &router.DecodeError{Err: err}
 			}
 
 			proceed, err := hc.checkWithinNetwork(ctx, idp.ContractAddress, idp.From, to.Hex())
@@ -89,14 +93,23 @@ func HandleTokenTransferInputData(hc *HandlerContainer) router.InputDataHandlerF
 				return nil
 			}
 
-			tokenTransferEvent.Payload = map[string]any{
-				"from":  idp.From,
-				"to":    to.Hex(),
-				"value": value.String(),
+			tokenTransferEvent.Payload = &event.TokenTransferPayload{
+				From:  idp.From,
+				To:    to.Hex(),
+				Value: value.String(),
 			}
 
 			return c(ctx, tokenTransferEvent)
 		case "23b872dd":
+			// ERC-721 transferFrom has the same selector, its last argument is the token id
+			erc721, err := hc.isERC721(ctx, idp.ContractAddress)
+			if err != nil {
+				return err
+			}
+			if erc721 {
+				return HandleNFTTransferInputData(hc)(ctx, idp, c)
+			}
+
 			var (
 				from  common.Address
 				to    common.Address
@@ -104,7 +117,7 @@ func HandleTokenTransferInputData(hc *HandlerContainer) router.InputDataHandlerF
 			)
 
 			if err := tokenTransferFromSig.DecodeArgs(w3.B(idp.InputData), &from, &to, &value); err != nil {
-				return err
+				return &router.DecodeError{Err: err}
 			}
 
 			proceed, err := hc.checkWithinNetwork(ctx, idp.ContractAddress, from.Hex(), to.Hex())
@@ -115,10 +128,10 @@ func HandleTokenTransferInputData(hc *HandlerContainer) router.InputDataHandlerF
 				return nil
 			}
 
-			tokenTransferEvent.Payload = map[string]any{
-				"from":  from.Hex(),
-				"to":    to.Hex(),
-				"value": value.String(),
+			tokenTransferEvent.Payload = &event.TokenTransferPayload{
+				From:  from.Hex(),
+				To:    to.Hex(),
+				Value: value.String(),
 			}
 
 			return c(ctx, tokenTransferEvent)
@@ -138,10 +151,10 @@ func HandleTokenTransferFromLog(hc *HandlerContainer) router.LogHandlerFunc {
 		)
 
 		if err := tokenTransferFromEvent.DecodeArgs(lp.Log, &from, &to, &spender, &value); err != nil {
-			return err
+			return &router.DecodeError{Err: err}
 		}
 
-		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), from.Hex(), to.Hex())
+		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), from.Hex(), to.Hex(), lp.Sender)
 		if err != nil {
 			return err
 		}
@@ -157,11 +170,11 @@ func HandleTokenTransferFromLog(hc *HandlerContainer) router.LogHandlerFunc {
 			Timestamp:       lp.Timestamp,
 			TxHash:          lp.Log.TxHash.Hex(),
 			TxType:          transferEventName,
-			Payload: map[string]any{
-				"from":    from.Hex(),
-				"to":      to.Hex(),
-				"spender": spender.Hex(),
-				"value":   value.String(),
+			Payload: &event.TokenTransferPayload{
+				From:    from.Hex(),
+				To:      to.Hex(),
+				Spender: spender.Hex(),
+				Value:   value.String(),
 			},
 		}
 
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+	"github.com/lmittmann/w3"
+)
+
+const (
+	userOperationEventName             = "USER_OPERATION"
+	accountDeployedEventName           = "ACCOUNT_DEPLOYED"
+	userOperationRevertReasonEventName = "USER_OPERATION_REVERT_REASON"
+)
+
+var (
+	userOperationEvent             = w3.MustNewEvent("UserOperationEvent(bytes32 indexed userOpHash, address indexed sender, address indexed paymaster, uint256 nonce, bool success, uint256 actualGasCost, uint256 actualGasUsed)")
+	accountDeployedEvent           = w3.MustNewEvent("AccountDeployed(bytes32 indexed userOpHash, address indexed sender, address factory, address paymaster)")
+	userOperationRevertReasonEvent = w3.MustNewEvent("UserOperationRevertReason(bytes32 indexed userOpHash, address indexed sender, uint256 nonce, bytes revertReason)")
+)
+
+func HandleUserOperationLog(hc *HandlerContainer) router.LogHandlerFunc {
+	return func(ctx context.Context, lp router.LogPayload, c router.Callback) error {
+		var (
+			userOpHash    common.Hash
+			sender        common.Address
+			paymaster     common.Address
+			nonce         big.Int
+			success       bool
+			actualGasCost big.Int
+			actualGasUsed big.Int
+		)
+
+		if err := userOperationEvent.DecodeArgs(
+			lp.Log,
+			&userOpHash,
+			&sender,
+			&paymaster,
+			&nonce,
+			&success,
+			&actualGasCost,
+			&actualGasUsed,
+		); err != nil {
+			return &router.DecodeError{Err: err}
+		}
+
+		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), sender.Hex(), paymaster.Hex())
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+
+		userOperationEvent := event.Event{
+			Index:           lp.Log.Index,
+			Block:           lp.Log.BlockNumber,
+			ContractAddress: lp.Log.Address.Hex(),
+			Success:         success,
+			Timestamp:       lp.Timestamp,
+			TxHash:          lp.Log.TxHash.Hex(),
+			TxType:          userOperationEventName,
+			Payload: &event.UserOperationPayload{
+				UserOpHash:    userOpHash.Hex(),
+				Sender:        sender.Hex(),
+				Paymaster:     paymaster.Hex(),
+				Nonce:         nonce.String(),
+				ActualGasCost: actualGasCost.String(),
+				ActualGasUsed: actualGasUsed.String(),
+			},
+		}
+
+		return c(ctx, userOperationEvent)
+	}
+}
+
+func HandleAccountDeployedLog(hc *HandlerContainer) router.LogHandlerFunc {
+	return func(ctx context.Context, lp router.LogPayload, c router.Callback) error {
+		var (
+			userOpHash common.Hash
+			sender     common.Address
+			factory    common.Address
+			paymaster  common.Address
+		)
+
+		if err := accountDeployedEvent.DecodeArgs(lp.Log, &userOpHash, &sender, &factory, &paymaster); err != nil {
+			return &router.DecodeError{Err: err}
+		}
+
+		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), sender.Hex(), factory.Hex(), paymaster.Hex())
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+
+		accountDeployedEvent := event.Event{
+			Index:           lp.Log.Index,
+			Block:           lp.Log.BlockNumber,
+			ContractAddress: lp.Log.Address.Hex(),
+			Success:         true,
+			Timestamp:       lp.Timestamp,
+			TxHash:          lp.Log.TxHash.Hex(),
+			TxType:          accountDeployedEventName,
+			Payload: &event.AccountDeployedPayload{
+				UserOpHash: userOpHash.Hex(),
+				Sender:     sender.Hex(),
+				Factory:    factory.Hex(),
+				Paymaster:  paymaster.Hex(),
+			},
+		}
+
+		// Smart accounts deployed by a tracked factory or paymaster are tracked the same way as contract creations
+		if err := hc.cache.Add(ctx, sender.Hex()); err != nil {
+			return err
+		}
+
+		return c(ctx, accountDeployedEvent)
+	}
+}
+
+func HandleUserOperationRevertReasonLog(hc *HandlerContainer) router.LogHandlerFunc {
+	return func(ctx context.Context, lp router.LogPayload, c router.Callback) error {
+		var (
+			userOpHash   common.Hash
+			sender       common.Address
+			nonce        big.Int
+			revertReason []byte
+		)
+
+		if err := userOperationRevertReasonEvent.DecodeArgs(lp.Log, &userOpHash, &sender, &nonce, &revertReason); err != nil {
+			return &router.DecodeError{Err: err}
+		}
+
+		proceed, err := hc.checkWithinNetwork(ctx, lp.Log.Address.Hex(), sender.Hex())
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			return nil
+		}
+
+		userOperationRevertReasonEvent := event.Event{
+			Index:           lp.Log.Index,
+			Block:           lp.Log.BlockNumber,
+			ContractAddress: lp.Log.Address.Hex(),
+			Success:         false,
+			Timestamp:       lp.Timestamp,
+			TxHash:          lp.Log.TxHash.Hex(),
+			TxType:          userOperationRevertReasonEventName,
+			Payload: &event.UserOperationRevertReasonPayload{
+				UserOpHash:   userOpHash.Hex(),
+				Sender:       sender.Hex(),
+				Nonce:        nonce.String(),
+				RevertReason: hexutil.Encode(revertReason),
+			},
+		}
+
+		return c(ctx, userOperationRevertReasonEvent)
+	}
+}
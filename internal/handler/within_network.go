@@ -2,8 +2,8 @@ package handler
 
 import "context"
 
-func (hc *HandlerContainer) checkWithinNetwork(ctx context.Context, contractAddress string, from string, to string) (bool, error) {
-	exists, err := hc.cache.ExistsNetwork(ctx, contractAddress, from, to)
+func (hc *HandlerContainer) checkWithinNetwork(ctx context.Context, contractAddress string, addresses ...string) (bool, error) {
+	exists, err := hc.cache.ExistsNetwork(ctx, contractAddress, addresses...)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,54 @@
+// Package outbox stages the events of a block in the local DB so that they are published by a relay after the block is committed
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+)
+
+type (
+	// Batch collects the events emitted while processing a single block
+	Batch struct {
+		mu     sync.Mutex
+		events [][]byte
+	}
+
+	batchKey struct{}
+)
+
+var ErrNoBatch = errors.New("outbox batch missing from context")
+
+// WithBatch returns a context whose staged events are collected into the returned batch
+func WithBatch(ctx context.Context) (context.Context, *Batch) {
+	batch := &Batch{}
+	return context.WithValue(ctx, batchKey{}, batch), batch
+}
+
+// Stage is a router callback that serializes the event into the batch of the context
+func Stage(ctx context.Context, ev event.Event) error {
+	batch, ok := ctx.Value(batchKey{}).(*Batch)
+	if !ok {
+		return ErrNoBatch
+	}
+
+	data, err := ev.Serialize()
+	if err != nil {
+		return err
+	}
+
+	batch.mu.Lock()
+	batch.events = append(batch.events, data)
+	batch.mu.Unlock()
+
+	return nil
+}
+
+func (b *Batch) Events() [][]byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.events
+}
@@ -0,0 +1,195 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/grassrootseconomics/eth-tracker/db"
+	"github.com/grassrootseconomics/eth-tracker/internal/pub"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+)
+
+type (
+	Store interface {
+		ReadOutbox(int) ([]db.OutboxEntry, error)
+		DeleteOutbox(...uint64) error
+		OutboxSize() (int, error)
+	}
+
+	RelayOpts struct {
+		Store Store
+		Pub   pub.Pub
+		// BatchSize is the number of events read from the outbox and deleted together after publishing
+		BatchSize int
+		// RetryInterval is the wait after a failed publish, the outbox is also polled at this interval
+		RetryInterval time.Duration
+		Logg          *slog.Logger
+	}
+
+	// Relay drains the outbox to the publisher in commit order
+	Relay struct {
+		store         Store
+		pub           pub.Pub
+		batchSize     int
+		retryInterval time.Duration
+		logg          *slog.Logger
+		notifyCh      chan struct{}
+		ctx           context.Context
+		cancel        context.CancelFunc
+		wg            sync.WaitGroup
+	}
+)
+
+const (
+	defaultRelayBatchSize     = 500
+	defaultRelayRetryInterval = 5 * time.Second
+)
+
+func NewRelay(o RelayOpts) *Relay {
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultRelayBatchSize
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = defaultRelayRetryInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := &Relay{
+		store:         o.Store,
+		pub:           o.Pub,
+		batchSize:     o.BatchSize,
+		retryInterval: o.RetryInterval,
+		logg:          o.Logg,
+		notifyCh:      make(chan struct{}, 1),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+
+	metrics.GetOrCreateGauge("outbox_size", func() float64 {
+		size, err := o.Store.OutboxSize()
+		if err != nil {
+			return 0
+		}
+		return float64(size)
+	})
+
+	return r
+}
+
+// Start relays in the background until Stop is called, staged events are left in the outbox and relayed on the next start
+func (r *Relay) Start() {
+	r.wg.Add(1)
+	go r.run()
+}
+
+func (r *Relay) run() {
+	defer r.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			r.logg.Debug("outbox relay shutting down")
+			return
+		case <-r.notifyCh:
+		case <-timer.C:
+		}
+
+		if err := r.drain(); err != nil && r.ctx.Err() == nil {
+			metrics.GetOrCreateCounter("outbox_relay_errors_total").Inc()
+			r.logg.Error("outbox relay error, retrying", "retry_in", r.retryInterval, "error", err)
+
+			// Wait out the retry interval, notifications would only retry immediately
+			select {
+			case <-r.ctx.Done():
+			case <-time.After(r.retryInterval):
+			}
+		}
+
+		timer.Reset(r.retryInterval)
+	}
+}
+
+func (r *Relay) Stop() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// Notify wakes up the relay after a block is committed
+func (r *Relay) Notify() {
+	select {
+	case r.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// drain publishes batches until the outbox is empty, events of a partially published batch can be sent again after a crash
+func (r *Relay) drain() error {
+	for {
+		entries, err := r.store.ReadOutbox(r.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		var (
+			sent   = make([]uint64, 0, len(entries))
+			blocks []uint64
+		)
+		for _, entry := range entries {
+			if r.ctx.Err() != nil {
+				break
+			}
+
+			ev, err := event.Deserialize(entry.Data)
+			if err != nil {
+				// Publishing an undecodable event can never succeed
+				r.logg.Error("dropping undecodable outbox event", "key", entry.Key, "error", err)
+				metrics.GetOrCreateCounter("outbox_dropped_total").Inc()
+				sent = append(sent, entry.Key)
+				continue
+			}
+
+			if err = r.pub.Send(r.ctx, ev); err != nil {
+				if err := r.commit(sent, blocks); err != nil {
+					r.logg.Error("could not delete relayed outbox events", "error", err)
+				}
+				return err
+			}
+			metrics.GetOrCreateCounter("outbox_relayed_total").Inc()
+			sent = append(sent, entry.Key)
+			if len(blocks) == 0 || blocks[len(blocks)-1] != ev.Block {
+				blocks = append(blocks, ev.Block)
+			}
+		}
+
+		if err := r.commit(sent, blocks); err != nil {
+			return err
+		}
+
+		if r.ctx.Err() != nil {
+			return r.ctx.Err()
+		}
+	}
+}
+
+// commit deletes sent events, they stay in the outbox until async publishes of their blocks are acknowledged
+func (r *Relay) commit(sent []uint64, blocks []uint64) error {
+	if flusher, ok := r.pub.(pub.BlockFlusher); ok {
+		for _, block := range blocks {
+			if err := flusher.FlushBlock(r.ctx, block); err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.store.DeleteOutbox(sent...)
+}
@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/db"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	testStore struct {
+		mu      sync.Mutex
+		seq     uint64
+		entries []db.OutboxEntry
+	}
+
+	testPub struct {
+		mu       sync.Mutex
+		sent     []string
+		failures int
+	}
+)
+
+func (s *testStore) commit(events [][]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, data := range events {
+		s.seq++
+		s.entries = append(s.entries, db.OutboxEntry{Key: s.seq, Data: data})
+	}
+}
+
+func (s *testStore) ReadOutbox(limit int) ([]db.OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]db.OutboxEntry(nil), s.entries[:min(limit, len(s.entries))]...), nil
+}
+
+func (s *testStore) DeleteOutbox(keys ...uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := make(map[uint64]struct{}, len(keys))
+	for _, k := range keys {
+		deleted[k] = struct{}{}
+	}
+
+	var entries []db.OutboxEntry
+	for _, entry := range s.entries {
+		if _, ok := deleted[entry.Key]; !ok {
+			entries = append(entries, entry)
+		}
+	}
+	s.entries = entries
+
+	return nil
+}
+
+func (s *testStore) OutboxSize() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.entries), nil
+}
+
+func (p *testPub) Send(_ context.Context, ev event.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("unavailable")
+	}
+	p.sent = append(p.sent, ev.TxHash)
+	return nil
+}
+
+func (p *testPub) Close() {}
+
+func (p *testPub) events() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]string(nil), p.sent...)
+}
+
+func stageBlock(t *testing.T, store *testStore, txHashes ...string) {
+	ctx, batch := WithBatch(context.Background())
+	for _, txHash := range txHashes {
+		require.NoError(t, Stage(ctx, event.Event{TxHash: txHash, TxType: "TOKEN_TRANSFER", Payload: &event.TokenTransferPayload{}}))
+	}
+	store.commit(batch.Events())
+}
+
+func TestStage_RequiresBatch(t *testing.T) {
+	require.ErrorIs(t, Stage(context.Background(), event.Event{}), ErrNoBatch)
+}
+
+func TestRelay_DrainsInOrderAcrossFailures(t *testing.T) {
+	var (
+		store     = &testStore{}
+		publisher = &testPub{failures: 2}
+	)
+
+	relay := NewRelay(RelayOpts{
+		Store:         store,
+		Pub:           publisher,
+		BatchSize:     2,
+		RetryInterval: 10 * time.Millisecond,
+		Logg:          slog.Default(),
+	})
+
+	stageBlock(t, store, "0x01", "0x02", "0x03")
+	store.commit([][]byte{[]byte("{")})
+
+	relay.Start()
+	defer relay.Stop()
+
+	stageBlock(t, store, "0x04")
+	relay.Notify()
+
+	require.Eventually(t, func() bool {
+		size, _ := store.OutboxSize()
+		return size == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Equal(t, []string{"0x01", "0x02", "0x03", "0x04"}, publisher.events())
+}
@@ -2,52 +2,378 @@ package pool
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
 
+	"github.com/VictoriaMetrics/metrics"
 	"github.com/alitto/pond/v2"
+	"github.com/grassrootseconomics/eth-tracker/db"
 	"github.com/grassrootseconomics/eth-tracker/internal/processor"
+	"github.com/grassrootseconomics/eth-tracker/internal/sequencer"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+	"github.com/puzpuzpuz/xsync/v3"
 )
 
 type (
+	// Lane is the priority of a pushed block, a free worker takes the oldest block of the highest priority lane that is below its share
+	Lane int
+
 	PoolOpts struct {
 		Logg        *slog.Logger
 		WorkerCount int
 		Processor   *processor.Processor
+		// LaneShares is the fraction of workers a lane can occupy, lanes without a share can use every worker
+		LaneShares map[Lane]float64
+		// MaxAttempts is the number of attempts after which a failing block is dead lettered, defaults to 5
+		MaxAttempts    int
+		InitialBackoff time.Duration
+		MaxBackoff     time.Duration
+		// DeadLetters persists blocks that keep failing, they are only logged when nil
+		DeadLetters DeadLetterStore
+		// Sequencer reserves a slot for every pushed block when ordering is enabled and skips dead lettered blocks
+		Sequencer *sequencer.Sequencer
+	}
+
+	DeadLetterStore interface {
+		PutDeadLetter(db.DeadLetter) error
+		GetDeadLetters() ([]db.DeadLetter, error)
+		DeleteDeadLetter(uint64) error
+		DiscardDeadLetter(uint64) error
 	}
 
 	Pool struct {
-		logg       *slog.Logger
-		workerPool pond.Pool
-		processor  *processor.Processor
+		logg           *slog.Logger
+		workerPool     pond.Pool
+		processBlock   func(context.Context, uint64) error
+		maxAttempts    int
+		initialBackoff time.Duration
+		maxBackoff     time.Duration
+		deadLetters    DeadLetterStore
+		sequencer      *sequencer.Sequencer
+		stopCh         chan struct{}
+		ctx            context.Context
+		cancel         context.CancelFunc
+		// inFlight holds blocks that are queued, processing or waiting for a retry
+		inFlight *xsync.MapOf[uint64, struct{}]
+
+		// Blocks wait in their lane until a worker is free, so pond never queues and the lane priority holds
+		mu          sync.Mutex
+		idle        *sync.Cond
+		queues      [laneCount][]task
+		running     [laneCount]int
+		laneLimits  [laneCount]int
+		freeWorkers int
+	}
+
+	task struct {
+		block   uint64
+		attempt int
 	}
 )
 
+const (
+	// LaneRealtime processes new heads from the syncer
+	LaneRealtime Lane = iota
+	// LaneReprocess processes blocks that are retried or requeued
+	LaneReprocess
+	// LaneBackfill processes missing historical blocks
+	LaneBackfill
+
+	laneCount = 3
+
+	// Error classes of failed blocks
+	ErrorClassTransient = "transient"
+	ErrorClassDecode    = "decode"
+
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 2 * time.Second
+	defaultMaxBackoff     = 2 * time.Minute
+)
+
+var laneNames = [laneCount]string{"realtime", "reprocess", "backfill"}
+
+func (l Lane) String() string {
+	return laneNames[l]
+}
+
+// Lanes returns every lane from highest to lowest priority
+func Lanes() []Lane {
+	return []Lane{LaneRealtime, LaneReprocess, LaneBackfill}
+}
+
 func New(o PoolOpts) *Pool {
-	return &Pool{
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = defaultMaxAttempts
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = defaultInitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaultMaxBackoff
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p := &Pool{
 		logg: o.Logg,
 		workerPool: pond.NewPool(
 			o.WorkerCount,
 		),
-		processor: o.Processor,
+		freeWorkers:    o.WorkerCount,
+		processBlock:   o.Processor.ProcessBlock,
+		maxAttempts:    o.MaxAttempts,
+		initialBackoff: o.InitialBackoff,
+		maxBackoff:     o.MaxBackoff,
+		deadLetters:    o.DeadLetters,
+		sequencer:      o.Sequencer,
+		stopCh:         make(chan struct{}),
+		ctx:            ctx,
+		cancel:         cancel,
+		inFlight:       xsync.NewMapOf[uint64, struct{}](),
+	}
+
+	p.idle = sync.NewCond(&p.mu)
+
+	// A free worker always takes the highest priority lane below its cap, a capped lane leaves the remaining workers to the other lanes
+	for _, lane := range Lanes() {
+		p.laneLimits[lane] = laneConcurrency(o.WorkerCount, o.LaneShares[lane])
+
+		metrics.GetOrCreateGauge(fmt.Sprintf(`pool_queue_size{lane=%q}`, lane), func() float64 {
+			return float64(p.LaneSize(lane))
+		})
 	}
+	metrics.GetOrCreateGauge("pool_in_flight", func() float64 {
+		return float64(p.InFlight())
+	})
+
+	return p
 }
 
+func laneConcurrency(workerCount int, share float64) int {
+	if share <= 0 || share >= 1 {
+		return workerCount
+	}
+
+	return max(1, int(share*float64(workerCount)))
+}
+
+// Stop processes every queued block before returning, scheduled retries are left to the backfiller
 func (p *Pool) Stop() {
+	close(p.stopCh)
+	p.cancel()
+
+	p.mu.Lock()
+	for p.pending() > 0 {
+		p.idle.Wait()
+	}
+	p.mu.Unlock()
+
 	p.workerPool.StopAndWait()
 }
 
-// non-blocking
-func (p *Pool) Push(block uint64) {
-	p.workerPool.Submit(func() {
-		err := p.processor.ProcessBlock(context.Background(), block)
-		if err != nil {
-			p.logg.Error("block processor error", "block_number", block, "error", err)
+// non-blocking unless ordering is enabled and the reorder buffer is full, pushing a block that is already in flight is a no-op
+func (p *Pool) Push(block uint64, lane Lane) {
+	if _, loaded := p.inFlight.LoadOrStore(block, struct{}{}); loaded {
+		metrics.GetOrCreateCounter(fmt.Sprintf(`pool_duplicates_suppressed_total{lane=%q}`, lane)).Inc()
+		p.logg.Debug("suppressed duplicate block push", "block_number", block, "lane", lane)
+		return
+	}
+
+	// The slot is taken in push order, a lower block is published first even if a higher one is processed faster
+	if p.sequencer != nil {
+		if err := p.sequencer.Reserve(p.ctx, block); err != nil {
+			p.inFlight.Delete(block)
+			return
+		}
+	}
+	p.submit(block, lane, 1)
+}
+
+func (p *Pool) submit(block uint64, lane Lane, attempt int) {
+	p.mu.Lock()
+	p.queues[lane] = append(p.queues[lane], task{block: block, attempt: attempt})
+	p.dispatch()
+	p.mu.Unlock()
+}
+
+// dispatch hands queued blocks to free workers in lane priority order, it must be called with mu held
+func (p *Pool) dispatch() {
+	for p.freeWorkers > 0 {
+		lane, ok := p.nextLane()
+		if !ok {
+			return
+		}
+
+		t := p.queues[lane][0]
+		p.queues[lane][0] = task{}
+		p.queues[lane] = p.queues[lane][1:]
+		p.running[lane]++
+		p.freeWorkers--
+
+		p.workerPool.Submit(func() {
+			p.run(t, lane)
+
+			p.mu.Lock()
+			p.running[lane]--
+			p.freeWorkers++
+			p.dispatch()
+			if p.pending() == 0 {
+				p.idle.Broadcast()
+			}
+			p.mu.Unlock()
+		})
+	}
+}
+
+func (p *Pool) nextLane() (Lane, bool) {
+	for _, lane := range Lanes() {
+		if len(p.queues[lane]) > 0 && p.running[lane] < p.laneLimits[lane] {
+			return lane, true
+		}
+	}
+
+	return 0, false
+}
+
+func (p *Pool) pending() int {
+	var pending int
+	for _, lane := range Lanes() {
+		pending += len(p.queues[lane]) + p.running[lane]
+	}
+
+	return pending
+}
+
+func (p *Pool) run(t task, lane Lane) {
+	err := p.processBlock(context.Background(), t.block)
+	if err != nil {
+		if p.retry(t.block, lane, t.attempt, err) {
+			return
+		}
+	}
+	p.inFlight.Delete(t.block)
+}
+
+// retry requeues failed blocks on the reprocess lane and reports whether a retry was scheduled,
+// decode errors and blocks out of attempts are dead lettered
+func (p *Pool) retry(block uint64, lane Lane, attempt int, err error) bool {
+	class := classify(err)
+	metrics.GetOrCreateCounter(fmt.Sprintf(`pool_block_failures_total{class=%q}`, class)).Inc()
+
+	if class == ErrorClassDecode || attempt >= p.maxAttempts {
+		p.logg.Error("block processor error, dead lettering block", "block_number", block, "lane", lane, "attempt", attempt, "class", class, "error", err)
+		p.deadLetter(block, attempt, class, err)
+		if p.sequencer != nil {
+			p.sequencer.Skip(block)
+		}
+		return false
+	}
+
+	delay := backoff(p.initialBackoff, p.maxBackoff, attempt)
+	p.logg.Warn("block processor error, retrying", "block_number", block, "lane", lane, "attempt", attempt, "class", class, "retry_in", delay, "error", err)
+	metrics.GetOrCreateCounter("pool_block_retries_total").Inc()
+
+	time.AfterFunc(delay, func() {
+		select {
+		case <-p.stopCh:
+			p.inFlight.Delete(block)
+		default:
+			p.submit(block, LaneReprocess, attempt+1)
 		}
 	})
+
+	return true
+}
+
+func (p *Pool) deadLetter(block uint64, attempt int, class string, err error) {
+	if p.deadLetters == nil {
+		return
+	}
+
+	if err := p.deadLetters.PutDeadLetter(db.DeadLetter{
+		Block:    block,
+		Attempts: attempt,
+		Class:    class,
+		Error:    err.Error(),
+		FailedAt: time.Now().UTC(),
+	}); err != nil {
+		p.logg.Error("could not persist dead letter", "block_number", block, "error", err)
+		return
+	}
+	metrics.GetOrCreateCounter("pool_dead_letters_total").Inc()
+}
+
+// DeadLetters returns the blocks that failed every attempt
+func (p *Pool) DeadLetters() ([]db.DeadLetter, error) {
+	if p.deadLetters == nil {
+		return nil, nil
+	}
+
+	return p.deadLetters.GetDeadLetters()
+}
+
+// Requeue removes the block from the dead letters and pushes it to the reprocess lane with a fresh set of attempts
+func (p *Pool) Requeue(block uint64) error {
+	if p.deadLetters != nil {
+		if err := p.deadLetters.DeleteDeadLetter(block); err != nil {
+			return err
+		}
+	}
+	p.Push(block, LaneReprocess)
+
+	return nil
+}
+
+// Discard gives up on a dead lettered block for good, it is marked as processed without publishing its events
+func (p *Pool) Discard(block uint64) error {
+	if p.deadLetters == nil {
+		return nil
+	}
+
+	return p.deadLetters.DiscardDeadLetter(block)
+}
+
+func classify(err error) string {
+	if router.IsDecodeError(err) {
+		return ErrorClassDecode
+	}
+
+	return ErrorClassTransient
+}
+
+// backoff doubles the delay per attempt, equal jitter keeps at least half of it
+func backoff(initial time.Duration, maxBackoff time.Duration, attempt int) time.Duration {
+	delay := initial << (attempt - 1)
+	if delay <= 0 || delay > maxBackoff {
+		delay = maxBackoff
+	}
+
+	return delay/2 + rand.N(delay/2+1)
 }
 
 func (p *Pool) Size() uint64 {
-	return p.workerPool.WaitingTasks()
+	var size uint64
+	for _, lane := range Lanes() {
+		size += p.LaneSize(lane)
+	}
+
+	return size
+}
+
+// LaneSize is the number of blocks of the lane waiting for a worker
+func (p *Pool) LaneSize(lane Lane) uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return uint64(len(p.queues[lane]))
+}
+
+// InFlight is the number of blocks queued, processing or waiting for a retry
+func (p *Pool) InFlight() int {
+	return p.inFlight.Size()
 }
 
 func (p *Pool) ActiveWorkers() int64 {
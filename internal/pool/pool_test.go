@@ -0,0 +1,257 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/grassrootseconomics/eth-tracker/db"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+	"github.com/lmittmann/w3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLaneConcurrency(t *testing.T) {
+	require.Equal(t, 12, laneConcurrency(12, 0))
+	require.Equal(t, 12, laneConcurrency(12, 1))
+	require.Equal(t, 6, laneConcurrency(12, 0.5))
+	require.Equal(t, 1, laneConcurrency(12, 0.01))
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 1; attempt <= 10; attempt++ {
+		delay := backoff(time.Second, time.Minute, attempt)
+		expected := min(time.Second<<(attempt-1), time.Minute)
+
+		require.GreaterOrEqual(t, delay, expected/2)
+		require.LessOrEqual(t, delay, expected)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	require.Equal(t, ErrorClassTransient, classify(fmt.Errorf("receipts fetch error: block 1: %w", context.DeadlineExceeded)))
+	require.Equal(t, ErrorClassTransient, classify(errors.New("w3: call failed: rate limited")))
+	require.Equal(t, ErrorClassDecode, classify(fmt.Errorf("transaction decode error: tx 0x01: %w", &router.DecodeError{Err: errors.New("invalid signature")})))
+	require.Equal(t, ErrorClassDecode, classify(fmt.Errorf("route success transaction error: tx 0x01: %w", &router.DecodeError{Err: w3.ErrArgumentMismatch})))
+	// Only explicitly wrapped errors are permanent, e.g. an RPC error mentioning the ABI is retried
+	require.Equal(t, ErrorClassTransient, classify(fmt.Errorf("route success transaction error: tx 0x01: %w", w3.ErrArgumentMismatch)))
+	require.Equal(t, ErrorClassTransient, classify(errors.New("route revert transaction error: tx 0x01: abi: cannot marshal in to go type")))
+}
+
+func TestPush_SuppressesInFlightBlocks(t *testing.T) {
+	p := New(PoolOpts{WorkerCount: 1, Logg: slog.Default()})
+	defer p.Stop()
+
+	suppressed := metrics.GetOrCreateCounter(`pool_duplicates_suppressed_total{lane="backfill"}`)
+	before := suppressed.Get()
+
+	// Blocks waiting for a retry stay in flight
+	p.inFlight.Store(10, struct{}{})
+	p.Push(10, LaneBackfill)
+
+	require.Equal(t, before+1, suppressed.Get())
+	require.Equal(t, 1, p.InFlight())
+	require.Zero(t, p.Size())
+}
+
+func TestPool_RealtimeBeforeSaturatedBackfill(t *testing.T) {
+	var (
+		started = make(chan uint64, 16)
+		unblock = make(chan struct{})
+	)
+
+	p := New(PoolOpts{WorkerCount: 2, Logg: slog.Default()})
+	p.processBlock = func(_ context.Context, block uint64) error {
+		started <- block
+		if block < 100 {
+			<-unblock
+		}
+		return nil
+	}
+
+	for block := uint64(1); block <= 10; block++ {
+		p.Push(block, LaneBackfill)
+	}
+	require.ElementsMatch(t, []uint64{1, 2}, []uint64{<-started, <-started})
+	require.Equal(t, uint64(8), p.LaneSize(LaneBackfill))
+
+	// Every worker is busy with backfill, the realtime block is taken by the next free worker
+	p.Push(100, LaneRealtime)
+	unblock <- struct{}{}
+	require.Equal(t, uint64(100), <-started)
+	require.Equal(t, uint64(3), <-started)
+
+	close(unblock)
+	p.Stop()
+	require.Zero(t, p.Size())
+	require.Zero(t, p.InFlight())
+}
+
+func TestPool_LaneShareCapsWorkers(t *testing.T) {
+	var (
+		started = make(chan uint64, 16)
+		unblock = make(chan struct{})
+	)
+
+	p := New(PoolOpts{WorkerCount: 4, LaneShares: map[Lane]float64{LaneBackfill: 0.5}, Logg: slog.Default()})
+	p.processBlock = func(_ context.Context, block uint64) error {
+		started <- block
+		<-unblock
+		return nil
+	}
+
+	for block := uint64(1); block <= 4; block++ {
+		p.Push(block, LaneBackfill)
+	}
+	<-started
+	<-started
+
+	// Half of the workers stay free for other lanes
+	require.Equal(t, uint64(2), p.LaneSize(LaneBackfill))
+	p.Push(100, LaneRealtime)
+	require.Equal(t, uint64(100), <-started)
+
+	close(unblock)
+	p.Stop()
+	require.Zero(t, p.InFlight())
+}
+
+type testDeadLetters struct {
+	mu          sync.Mutex
+	deadLetters map[uint64]db.DeadLetter
+}
+
+func (s *testDeadLetters) PutDeadLetter(deadLetter db.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deadLetters[deadLetter.Block] = deadLetter
+	return nil
+}
+
+func (s *testDeadLetters) GetDeadLetters() ([]db.DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deadLetters []db.DeadLetter
+	for _, deadLetter := range s.deadLetters {
+		deadLetters = append(deadLetters, deadLetter)
+	}
+	return deadLetters, nil
+}
+
+func (s *testDeadLetters) DeleteDeadLetter(block uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.deadLetters, block)
+	return nil
+}
+
+func (s *testDeadLetters) DiscardDeadLetter(block uint64) error {
+	return s.DeleteDeadLetter(block)
+}
+
+type testAttempt struct {
+	at        time.Time
+	reprocess bool
+}
+
+// newFailingPool returns a pool whose processor fails with err, the attempts record when each one started and on which lane
+func newFailingPool(maxAttempts int, err error) (*Pool, *testDeadLetters, chan testAttempt) {
+	var (
+		deadLetters = &testDeadLetters{deadLetters: make(map[uint64]db.DeadLetter)}
+		attempts    = make(chan testAttempt, 16)
+	)
+
+	p := New(PoolOpts{
+		WorkerCount:    1,
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: 40 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		DeadLetters:    deadLetters,
+		Logg:           slog.Default(),
+	})
+	p.processBlock = func(_ context.Context, _ uint64) error {
+		p.mu.Lock()
+		reprocess := p.running[LaneReprocess] > 0
+		p.mu.Unlock()
+
+		attempts <- testAttempt{at: time.Now(), reprocess: reprocess}
+		return err
+	}
+
+	return p, deadLetters, attempts
+}
+
+func TestPool_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	p, deadLetters, attempts := newFailingPool(4, errors.New("w3: call failed: rate limited"))
+	defer p.Stop()
+
+	p.Push(10, LaneRealtime)
+
+	var history []testAttempt
+	for range 4 {
+		select {
+		case attempt := <-attempts:
+			history = append(history, attempt)
+		case <-time.After(5 * time.Second):
+			t.Fatal("block was not retried")
+		}
+	}
+
+	// Retries run on the reprocess lane and wait at least half of the doubled backoff
+	require.False(t, history[0].reprocess)
+	for i := 1; i < len(history); i++ {
+		require.True(t, history[i].reprocess)
+		require.GreaterOrEqual(t, history[i].at.Sub(history[i-1].at), (40*time.Millisecond<<(i-1))/2)
+	}
+	require.Greater(t, history[3].at.Sub(history[2].at), history[1].at.Sub(history[0].at))
+
+	require.Eventually(t, func() bool {
+		return p.InFlight() == 0
+	}, time.Second, 5*time.Millisecond)
+	stored, err := p.DeadLetters()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	require.Equal(t, uint64(10), stored[0].Block)
+	require.Equal(t, 4, stored[0].Attempts)
+	require.Equal(t, ErrorClassTransient, stored[0].Class)
+
+	// No further attempts once the block is dead lettered
+	select {
+	case <-attempts:
+		t.Fatal("dead lettered block was retried")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// A requeued block gets a fresh set of attempts
+	require.NoError(t, p.Requeue(10))
+	stored, err = deadLetters.GetDeadLetters()
+	require.NoError(t, err)
+	require.Empty(t, stored)
+	require.True(t, (<-attempts).reprocess)
+}
+
+func TestPool_DecodeErrorIsDeadLetteredImmediately(t *testing.T) {
+	p, _, attempts := newFailingPool(5, fmt.Errorf("route success transaction error: tx 0x01: %w", &router.DecodeError{Err: errors.New("invalid signature")}))
+	defer p.Stop()
+
+	p.Push(20, LaneBackfill)
+	<-attempts
+
+	require.Eventually(t, func() bool {
+		return p.InFlight() == 0
+	}, time.Second, 5*time.Millisecond)
+	stored, err := p.DeadLetters()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	require.Equal(t, 1, stored[0].Attempts)
+	require.Equal(t, ErrorClassDecode, stored[0].Class)
+	require.Empty(t, attempts)
+}
@@ -0,0 +1,38 @@
+package processor
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+)
+
+type eventCountKey struct{}
+
+// CountEvents wraps the router callback to count the events published for the block being processed
+func CountEvents(next router.Callback) router.Callback {
+	return func(ctx context.Context, ev event.Event) error {
+		if err := next(ctx, ev); err != nil {
+			return err
+		}
+
+		if count, ok := ctx.Value(eventCountKey{}).(*atomic.Uint64); ok {
+			count.Add(1)
+		}
+		return nil
+	}
+}
+
+func withEventCount(ctx context.Context) context.Context {
+	return context.WithValue(ctx, eventCountKey{}, &atomic.Uint64{})
+}
+
+func publishedEvents(ctx context.Context) uint {
+	count, ok := ctx.Value(eventCountKey{}).(*atomic.Uint64)
+	if !ok {
+		return 0
+	}
+
+	return uint(count.Load())
+}
@@ -5,56 +5,159 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/grassrootseconomics/eth-tracker/db"
 	"github.com/grassrootseconomics/eth-tracker/internal/cache"
 	"github.com/grassrootseconomics/eth-tracker/internal/chain"
+	"github.com/grassrootseconomics/eth-tracker/internal/handler"
+	"github.com/grassrootseconomics/eth-tracker/internal/outbox"
+	"github.com/grassrootseconomics/eth-tracker/internal/revert"
+	"github.com/grassrootseconomics/eth-tracker/internal/sequencer"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
 	"github.com/grassrootseconomics/eth-tracker/pkg/router"
 )
 
 type (
 	ProcessorOpts struct {
-		Cache  cache.Cache
-		Chain  chain.Chain
-		DB     db.DB
-		Router *router.Router
-		Logg   *slog.Logger
+		Cache         cache.Cache
+		Chain         chain.Chain
+		DB            db.DB
+		Router        *router.Router
+		RevertDecoder *revert.Decoder
+		// Outbox stages events in the DB when set, the router callback must then be outbox.Stage
+		Outbox *outbox.Relay
+		// Sequencer publishes events in block order when set, the router callback must then be sequencer.Collect
+		Sequencer *sequencer.Sequencer
+		// Flush waits for the publisher to acknowledge the events of a block before it is marked as processed
+		Flush func(context.Context, uint64) error
+		// BlockMarker publishes a BLOCK_PROCESSED event after the events of every block when set,
+		// the router callback must then be wrapped with CountEvents
+		BlockMarker router.Callback
+		EntryPoints []string
+		Logg        *slog.Logger
 	}
 
 	Processor struct {
-		cache  cache.Cache
-		chain  chain.Chain
-		db     db.DB
-		router *router.Router
-		logg   *slog.Logger
+		cache         cache.Cache
+		chain         chain.Chain
+		db            db.DB
+		router        *router.Router
+		revertDecoder *revert.Decoder
+		outbox        *outbox.Relay
+		sequencer     *sequencer.Sequencer
+		flush         func(context.Context, uint64) error
+		blockMarker   router.Callback
+		entryPoints   map[common.Address]struct{}
+		logg          *slog.Logger
 	}
 )
 
 func NewProcessor(o ProcessorOpts) *Processor {
+	entryPoints := make(map[common.Address]struct{}, len(o.EntryPoints))
+	for _, entryPoint := range o.EntryPoints {
+		if entryPoint != "" {
+			entryPoints[common.HexToAddress(entryPoint)] = struct{}{}
+		}
+	}
+
 	return &Processor{
-		cache:  o.Cache,
-		chain:  o.Chain,
-		db:     o.DB,
-		router: o.Router,
-		logg:   o.Logg,
+		cache:         o.Cache,
+		chain:         o.Chain,
+		db:            o.DB,
+		router:        o.Router,
+		revertDecoder: o.RevertDecoder,
+		outbox:        o.Outbox,
+		sequencer:     o.Sequencer,
+		flush:         o.Flush,
+		blockMarker:   o.BlockMarker,
+		entryPoints:   entryPoints,
+		logg:          o.Logg,
 	}
 }
 
 func (p *Processor) ProcessBlock(ctx context.Context, blockNumber uint64) error {
+	var batch *outbox.Batch
+	if p.outbox != nil {
+		ctx, batch = outbox.WithBatch(ctx)
+	}
+
+	var ordered *sequencer.Batch
+	if p.sequencer != nil {
+		if err := p.sequencer.Begin(ctx, blockNumber); err != nil {
+			return err
+		}
+		ctx, ordered = sequencer.WithBatch(ctx)
+	}
+
+	if p.blockMarker != nil {
+		ctx = withEventCount(ctx)
+	}
+
+	if err := p.routeBlock(ctx, blockNumber); err != nil {
+		if p.sequencer != nil {
+			p.sequencer.Fail(blockNumber)
+		}
+		if p.flush != nil {
+			// Releases the acks of events already sent, the block is reprocessed anyway
+			if flushErr := p.flush(ctx, blockNumber); flushErr != nil {
+				err = errors.Join(err, fmt.Errorf("publish flush error: block %d: %w", blockNumber, flushErr))
+			}
+		}
+		return err
+	}
+
+	// The sequencer marks the block as processed once every lower block is published
+	if p.sequencer != nil {
+		if err := p.sequencer.Release(blockNumber, ordered.Events(), func() error {
+			return p.db.SetValue(blockNumber)
+		}); err != nil {
+			return fmt.Errorf("ordered publish error: block %d: %w", blockNumber, err)
+		}
+		p.logg.Debug("successfully processed block, waiting for ordered publish", "block", blockNumber)
+
+		return nil
+	}
+
+	if p.flush != nil {
+		if err := p.flush(ctx, blockNumber); err != nil {
+			return fmt.Errorf("publish flush error: block %d: %w", blockNumber, err)
+		}
+	}
+
+	if batch != nil {
+		if err := p.db.CommitBlock(blockNumber, batch.Events()); err != nil {
+			return err
+		}
+		p.outbox.Notify()
+	} else if err := p.db.SetValue(blockNumber); err != nil {
+		return err
+	}
+	p.logg.Debug("successfully processed block", "block", blockNumber)
+
+	return nil
+}
+
+// routeBlock routes every tracked log, transaction and contract creation of the block to its handler, followed by the block marker
+func (p *Processor) routeBlock(ctx context.Context, blockNumber uint64) error {
 	block, err := p.chain.GetBlock(ctx, blockNumber)
 	if err != nil && !errors.Is(err, context.Canceled) {
-		return fmt.Errorf("block %d error: %v", blockNumber, err)
+		return fmt.Errorf("block %d error: %w", blockNumber, err)
 	}
 
 	receipts, err := p.chain.GetReceipts(ctx, block.Number())
 	if err != nil && !errors.Is(err, context.Canceled) {
-		return fmt.Errorf("receipts fetch error: block %d: %v", blockNumber, err)
+		return fmt.Errorf("receipts fetch error: block %d: %w", blockNumber, err)
 	}
 
 	for _, receipt := range receipts {
+		txFee := txFee(receipt)
+
 		if receipt.Status == 1 {
+			userOperationSenders := p.userOperationSenders(receipt.Logs)
+
 			for _, log := range receipt.Logs {
 				exists, err := p.cache.Exists(ctx, log.Address.Hex())
 				if err != nil {
@@ -66,9 +169,46 @@ func (p *Processor) ProcessBlock(ctx context.Context, blockNumber uint64) error
 						router.LogPayload{
 							Log:       log,
 							Timestamp: block.Time(),
+							Sender:    userOperationSenders[log.Index],
+							Tx:        txFee,
 						},
 					); err != nil && !errors.Is(err, context.Canceled) {
-						return fmt.Errorf("route success transaction error: tx %s: %v", receipt.TxHash.Hex(), err)
+						return fmt.Errorf("route success transaction error: tx %s: %w", receipt.TxHash.Hex(), err)
+					}
+				}
+			}
+
+			if int(receipt.TransactionIndex) < len(block.Transactions()) {
+				tx := block.Transactions()[receipt.TransactionIndex]
+				if tx.To() != nil && p.router.HasSuccessInputDataRoute(tx.Data()) {
+					exists, err := p.cache.Exists(ctx, tx.To().Hex())
+					if err != nil {
+						return err
+					}
+					if exists {
+						from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+						if err != nil {
+							return fmt.Errorf("transaction decode error: tx %s: %w", receipt.TxHash.Hex(), &router.DecodeError{Err: err})
+						}
+
+						if err := p.router.ProcessSuccessInputData(
+							ctx,
+							router.InputDataPayload{
+								From:            from.Hex(),
+								InputData:       common.Bytes2Hex(tx.Data()),
+								Block:           blockNumber,
+								ContractAddress: tx.To().Hex(),
+								Timestamp:       block.Time(),
+								TxHash:          receipt.TxHash.Hex(),
+								TxIndex:         receipt.TransactionIndex,
+								BlockHash:       receipt.BlockHash.Hex(),
+								Success:         true,
+								Logs:            receipt.Logs,
+								Tx:              txFee,
+							},
+						); err != nil && !errors.Is(err, context.Canceled) {
+							return fmt.Errorf("route success input data error: tx %s: %w", receipt.TxHash.Hex(), err)
+						}
 					}
 				}
 			}
@@ -76,12 +216,12 @@ func (p *Processor) ProcessBlock(ctx context.Context, blockNumber uint64) error
 			if receipt.ContractAddress != (common.Address{}) {
 				tx, err := p.chain.GetTransaction(ctx, receipt.TxHash)
 				if err != nil && !errors.Is(err, context.Canceled) {
-					return fmt.Errorf("get transaction error: tx %s: %v", receipt.TxHash.Hex(), err)
+					return fmt.Errorf("get transaction error: tx %s: %w", receipt.TxHash.Hex(), err)
 				}
 
 				from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
 				if err != nil {
-					return fmt.Errorf("transaction decode error: tx %s: %v", receipt.TxHash.Hex(), err)
+					return fmt.Errorf("transaction decode error: tx %s: %w", receipt.TxHash.Hex(), &router.DecodeError{Err: err})
 				}
 
 				exists, err := p.cache.Exists(ctx, from.Hex())
@@ -97,10 +237,13 @@ func (p *Processor) ProcessBlock(ctx context.Context, blockNumber uint64) error
 							ContractAddress: receipt.ContractAddress.Hex(),
 							Timestamp:       block.Time(),
 							TxHash:          receipt.TxHash.Hex(),
+							TxIndex:         receipt.TransactionIndex,
+							BlockHash:       receipt.BlockHash.Hex(),
 							Success:         true,
+							Tx:              txFee,
 						},
 					); err != nil && !errors.Is(err, context.Canceled) {
-						return fmt.Errorf("route success contract creation error: tx %s: %v", receipt.TxHash.Hex(), err)
+						return fmt.Errorf("route success contract creation error: tx %s: %w", receipt.TxHash.Hex(), err)
 					}
 				}
 			}
@@ -109,12 +252,12 @@ func (p *Processor) ProcessBlock(ctx context.Context, blockNumber uint64) error
 		if receipt.Status == 0 {
 			tx, err := p.chain.GetTransaction(ctx, receipt.TxHash)
 			if err != nil && !errors.Is(err, context.Canceled) {
-				return fmt.Errorf("get transaction error: tx %s: %v", receipt.TxHash.Hex(), err)
+				return fmt.Errorf("get transaction error: tx %s: %w", receipt.TxHash.Hex(), err)
 			}
 			if tx.To() == nil {
 				from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
 				if err != nil {
-					return fmt.Errorf("transaction decode error: tx %s: %v", receipt.TxHash.Hex(), err)
+					return fmt.Errorf("transaction decode error: tx %s: %w", receipt.TxHash.Hex(), &router.DecodeError{Err: err})
 				}
 
 				exists, err := p.cache.Exists(ctx, from.Hex())
@@ -131,45 +274,107 @@ func (p *Processor) ProcessBlock(ctx context.Context, blockNumber uint64) error
 							ContractAddress: receipt.ContractAddress.Hex(),
 							Timestamp:       block.Time(),
 							TxHash:          receipt.TxHash.Hex(),
+							TxIndex:         receipt.TransactionIndex,
+							BlockHash:       receipt.BlockHash.Hex(),
 							Success:         false,
+							RevertReason:    p.revertReason(ctx, tx, from, blockNumber),
+							Tx:              txFee,
 						},
 					); err != nil && !errors.Is(err, context.Canceled) {
-						return fmt.Errorf("route reverted contract creation error: tx %s: %v", receipt.TxHash.Hex(), err)
+						return fmt.Errorf("route reverted contract creation error: tx %s: %w", receipt.TxHash.Hex(), err)
 					}
 				}
 			} else {
-				exists, err := p.cache.Exists(ctx, tx.To().Hex())
-				if err != nil {
-					return err
-				}
-				if exists {
-					from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+				var (
+					from         common.Address
+					revertReason string
+					resolved     bool
+				)
+
+				// Inner calls of batching and proxy contracts are routed against the contract they target
+				payloads := router.UnwrapInputData(router.InputDataPayload{
+					InputData:       common.Bytes2Hex(tx.Data()),
+					Block:           blockNumber,
+					ContractAddress: tx.To().Hex(),
+					Timestamp:       block.Time(),
+					TxHash:          receipt.TxHash.Hex(),
+					TxIndex:         receipt.TransactionIndex,
+					BlockHash:       receipt.BlockHash.Hex(),
+					Tx:              txFee,
+				})
+				for _, payload := range payloads {
+					exists, err := p.cache.Exists(ctx, payload.ContractAddress)
 					if err != nil {
-						return fmt.Errorf("transaction decode error: tx %s: %v", receipt.TxHash.Hex(), err)
+						return err
+					}
+					if !exists {
+						continue
 					}
 
-					if err := p.router.ProcessInputData(
-						ctx,
-						router.InputDataPayload{
-							From:            from.Hex(),
-							InputData:       common.Bytes2Hex(tx.Data()),
-							Block:           blockNumber,
-							ContractAddress: tx.To().Hex(),
-							Timestamp:       block.Time(),
-							TxHash:          receipt.TxHash.Hex(),
-						},
-					); err != nil && !errors.Is(err, context.Canceled) {
-						return fmt.Errorf("route revert transaction error: tx %s: %v", receipt.TxHash.Hex(), err)
+					if !resolved {
+						from, err = types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+						if err != nil {
+							return fmt.Errorf("transaction decode error: tx %s: %w", receipt.TxHash.Hex(), &router.DecodeError{Err: err})
+						}
+						revertReason = p.revertReason(ctx, tx, from, blockNumber)
+						resolved = true
+					}
+
+					// Only the outer sender is unknown while unwrapping
+					if payload.From == "" {
+						payload.From = from.Hex()
+					}
+					payload.RevertReason = revertReason
+
+					if err := p.router.ProcessInputData(ctx, payload); err != nil && !errors.Is(err, context.Canceled) {
+						return fmt.Errorf("route revert transaction error: tx %s: %w", receipt.TxHash.Hex(), err)
 					}
 				}
 			}
 		}
 	}
 
-	if err := p.db.SetValue(blockNumber); err != nil {
-		return err
+	if p.blockMarker != nil {
+		if err := handler.HandleBlockProcessed(ctx, block, publishedEvents(ctx), p.blockMarker); err != nil && !errors.Is(err, context.Canceled) {
+			return fmt.Errorf("block marker error: block %d: %w", blockNumber, err)
+		}
 	}
-	p.logg.Debug("successfully processed block", "block", blockNumber)
 
 	return nil
 }
+
+func txFee(receipt *chain.Receipt) *event.Tx {
+	txFee := &event.Tx{
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: "0",
+		Fee:               "0",
+	}
+
+	if receipt.EffectiveGasPrice != nil {
+		txFee.EffectiveGasPrice = receipt.EffectiveGasPrice.String()
+		txFee.Fee = new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice).String()
+	}
+	if receipt.FeeCurrency != nil {
+		txFee.FeeCurrency = receipt.FeeCurrency.Hex()
+	}
+	if receipt.L1Fee != nil {
+		txFee.L1Fee = receipt.L1Fee.String()
+	}
+
+	return txFee
+}
+
+// revertReason is best effort, replay failures are logged and published with an empty reason
+func (p *Processor) revertReason(ctx context.Context, tx *types.Transaction, from common.Address, blockNumber uint64) string {
+	if p.revertDecoder == nil || blockNumber == 0 {
+		return ""
+	}
+
+	revertData, err := p.chain.GetRevertData(ctx, tx, from, new(big.Int).SetUint64(blockNumber-1))
+	if err != nil {
+		p.logg.Warn("could not replay reverted transaction", "tx", tx.Hash().Hex(), "error", err)
+		return ""
+	}
+
+	return p.revertDecoder.Decode(revertData)
+}
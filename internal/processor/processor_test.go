@@ -0,0 +1,228 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/grassrootseconomics/eth-tracker/db"
+	"github.com/grassrootseconomics/eth-tracker/internal/cache"
+	"github.com/grassrootseconomics/eth-tracker/internal/chain"
+	"github.com/grassrootseconomics/eth-tracker/internal/handler"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/router"
+	"github.com/lmittmann/w3"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testToken         = w3.A("0x765DE816845861e75A25fCA122bb6898B8B1282a")
+	testAlice         = w3.A("0x000000000000000000000000000000000000a11c")
+	testBob           = w3.A("0x0000000000000000000000000000000000000b0b")
+	testUntracked     = w3.A("0x0000000000000000000000000000000000000bad")
+	testTransferTopic = w3.H("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+)
+
+type (
+	// testChain serves blocks and receipts from memory
+	testChain struct {
+		chain.Chain
+		receipts    map[uint64][]*chain.Receipt
+		receiptsErr error
+	}
+
+	testDB struct {
+		db.DB
+		mu        sync.Mutex
+		processed []uint64
+	}
+
+	testPublisher struct {
+		mu     sync.Mutex
+		events []event.Event
+	}
+)
+
+func (c *testChain) GetBlock(_ context.Context, blockNumber uint64) (*types.Block, error) {
+	return types.NewBlockWithHeader(&types.Header{
+		Number: new(big.Int).SetUint64(blockNumber),
+		Time:   1_700_000_000 + blockNumber,
+	}), nil
+}
+
+func (c *testChain) GetReceipts(_ context.Context, blockNumber *big.Int) ([]*chain.Receipt, error) {
+	return c.receipts[blockNumber.Uint64()], c.receiptsErr
+}
+
+func (d *testDB) SetValue(v uint64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.processed = append(d.processed, v)
+	return nil
+}
+
+func (p *testPublisher) publish(_ context.Context, ev event.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, ev)
+	return nil
+}
+
+// blockEvents returns the events published for the block in publish order
+func (p *testPublisher) blockEvents(block uint64) []event.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var events []event.Event
+	for _, ev := range p.events {
+		if ev.Block == block {
+			events = append(events, ev)
+		}
+	}
+
+	return events
+}
+
+func transferReceipt(blockNumber uint64, txIndex uint, logs ...*types.Log) *chain.Receipt {
+	txHash := common.BigToHash(new(big.Int).SetUint64(blockNumber*100 + uint64(txIndex)))
+	for _, log := range logs {
+		log.BlockNumber = blockNumber
+		log.TxHash = txHash
+		log.TxIndex = txIndex
+	}
+
+	return &chain.Receipt{
+		Receipt: &types.Receipt{
+			Status:           types.ReceiptStatusSuccessful,
+			TxHash:           txHash,
+			TransactionIndex: txIndex,
+			Logs:             logs,
+		},
+	}
+}
+
+func transferLog(contract common.Address, index uint) *types.Log {
+	return &types.Log{
+		Address: contract,
+		Topics: []common.Hash{
+			testTransferTopic,
+			common.BytesToHash(testAlice.Bytes()),
+			common.BytesToHash(testBob.Bytes()),
+		},
+		Data:  common.LeftPadBytes(big.NewInt(100).Bytes(), 32),
+		Index: index,
+	}
+}
+
+func newTestProcessor(t *testing.T, receipts map[uint64][]*chain.Receipt) (*Processor, *testPublisher) {
+	ctx := context.Background()
+
+	testCache := cache.NewMapCache()
+	for _, address := range []common.Address{testToken, testAlice, testBob} {
+		require.NoError(t, testCache.Add(ctx, address.Hex()))
+	}
+
+	var (
+		publisher = &testPublisher{}
+		testChain = &testChain{receipts: receipts}
+	)
+
+	r := router.New(CountEvents(publisher.publish))
+	r.RegisterLogRoute(testTransferTopic, handler.HandleTokenTransferLog(handler.New(testCache, testChain)))
+
+	return NewProcessor(ProcessorOpts{
+		Cache:       testCache,
+		Chain:       testChain,
+		DB:          &testDB{},
+		Router:      r,
+		BlockMarker: publisher.publish,
+		Logg:        slog.Default(),
+	}), publisher
+}
+
+func TestProcessBlock_BlockMarker(t *testing.T) {
+	p, publisher := newTestProcessor(t, map[uint64][]*chain.Receipt{
+		10: {
+			transferReceipt(10, 0, transferLog(testToken, 0), transferLog(testUntracked, 1)),
+			transferReceipt(10, 1, transferLog(testToken, 2)),
+		},
+		// Block 11 has no tracked activity
+		11: {
+			transferReceipt(11, 0, transferLog(testUntracked, 0)),
+		},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, p.ProcessBlock(ctx, 10))
+	require.NoError(t, p.ProcessBlock(ctx, 11))
+
+	events := publisher.blockEvents(10)
+	require.Len(t, events, 3)
+	require.Equal(t, []string{"TOKEN_TRANSFER", "TOKEN_TRANSFER", "BLOCK_PROCESSED"}, []string{events[0].TxType, events[1].TxType, events[2].TxType})
+	require.Equal(t, []uint{0, 2}, []uint{events[0].Index, events[1].Index})
+
+	marker := events[2]
+	require.Equal(t, &event.BlockProcessedPayload{EventCount: 2}, marker.Payload)
+	require.Equal(t, uint64(1_700_000_010), marker.Timestamp)
+	require.Equal(t, common.Address{}.Hex(), marker.ContractAddress)
+
+	events = publisher.blockEvents(11)
+	require.Len(t, events, 1)
+	require.Equal(t, "BLOCK_PROCESSED", events[0].TxType)
+	require.Equal(t, &event.BlockProcessedPayload{EventCount: 0}, events[0].Payload)
+}
+
+func TestProcessBlock_BlockMarkerCountsPerBlock(t *testing.T) {
+	receipts := make(map[uint64][]*chain.Receipt)
+	for block := uint64(1); block <= 8; block++ {
+		for i := uint(0); i < uint(block); i++ {
+			receipts[block] = append(receipts[block], transferReceipt(block, i, transferLog(testToken, i)))
+		}
+	}
+	p, publisher := newTestProcessor(t, receipts)
+
+	// Blocks processed concurrently keep separate counts
+	var wg sync.WaitGroup
+	for block := uint64(1); block <= 8; block++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, p.ProcessBlock(context.Background(), block))
+		}()
+	}
+	wg.Wait()
+
+	for block := uint64(1); block <= 8; block++ {
+		events := publisher.blockEvents(block)
+		require.Len(t, events, int(block)+1)
+		require.Equal(t, &event.BlockProcessedPayload{EventCount: uint(block)}, events[block].Payload)
+	}
+}
+
+func TestProcessBlock_FailureKeepsFlushError(t *testing.T) {
+	var (
+		receiptsErr = errors.New("receipts unavailable")
+		flushErr    = errors.New("ack timeout")
+		flushed     []uint64
+	)
+
+	p, _ := newTestProcessor(t, nil)
+	p.chain.(*testChain).receiptsErr = receiptsErr
+	p.flush = func(_ context.Context, block uint64) error {
+		flushed = append(flushed, block)
+		return flushErr
+	}
+
+	// The acks of a failed block are still released and a failed flush is reported alongside the processing error
+	err := p.ProcessBlock(context.Background(), 10)
+	require.ErrorIs(t, err, receiptsErr)
+	require.ErrorIs(t, err, flushErr)
+	require.Equal(t, []uint64{10}, flushed)
+}
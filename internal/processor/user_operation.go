@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+)
+
+var (
+	userOperationEventTopic = w3.H("0x49628fd1471006c1482da88028e9ce4dbb080b815c9b0344d39e5a8e6ec1419f")
+	beforeExecutionTopic    = w3.H("0xbb47ee3e183a558b1a2ff0874b079f3fc5478b7454eacf2bfc5af2ff5878f972")
+)
+
+// userOperationSenders maps the index of every log emitted while executing a UserOperation to its smart account.
+// The EntryPoint emits UserOperationEvent after the logs of the op it describes, so the receipt is walked backwards.
+func (p *Processor) userOperationSenders(logs []*types.Log) map[uint]string {
+	if len(p.entryPoints) == 0 {
+		return nil
+	}
+
+	var (
+		senders       map[uint]string
+		currentSender string
+	)
+
+	for i := len(logs) - 1; i >= 0; i-- {
+		log := logs[i]
+
+		if _, ok := p.entryPoints[log.Address]; ok && len(log.Topics) > 0 {
+			switch log.Topics[0] {
+			case userOperationEventTopic:
+				if len(log.Topics) > 2 {
+					currentSender = common.BytesToAddress(log.Topics[2].Bytes()).Hex()
+				}
+				continue
+			case beforeExecutionTopic:
+				currentSender = ""
+				continue
+			}
+		}
+
+		if currentSender != "" {
+			if senders == nil {
+				senders = make(map[uint]string)
+			}
+			senders[log.Index] = currentSender
+		}
+	}
+
+	return senders
+}
@@ -0,0 +1,550 @@
+package pub
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const (
+	ArchiveFormatJSONL   = "jsonl"
+	ArchiveFormatParquet = "parquet"
+)
+
+type (
+	ArchiveOpts struct {
+		Dir string
+		// Format is jsonl (gzip compressed) or parquet (snappy compressed)
+		Format string
+		// A file is rotated once it holds MaxEvents events or has been open for RotateInterval
+		MaxEvents      int
+		RotateInterval time.Duration
+		// MaxOpenFiles bounds the partitions written concurrently, the least recently written file is completed first
+		MaxOpenFiles int
+		Logg         *slog.Logger
+	}
+
+	archivePub struct {
+		dir            string
+		format         string
+		maxEvents      int
+		rotateInterval time.Duration
+		maxOpenFiles   int
+		logg           *slog.Logger
+
+		mu       sync.Mutex
+		files    map[archivePartition]*archiveFile
+		writes   uint64
+		manifest *os.File
+		stopCh   chan struct{}
+		wg       sync.WaitGroup
+	}
+
+	archivePartition struct {
+		date   string
+		txType string
+	}
+
+	archiveFile struct {
+		name     string
+		file     *os.File
+		jsonl    *gzip.Writer
+		parquet  *writer.ParquetWriter
+		openedAt time.Time
+		// lastWrite orders files for eviction, lastBlock and dirty track the JSONL data not yet flushed
+		lastWrite uint64
+		lastBlock uint64
+		dirty     bool
+		manifest  ArchiveManifestEntry
+	}
+
+	// ArchiveManifestEntry is appended to manifest.jsonl for every completed archive file
+	ArchiveManifestEntry struct {
+		File      string `json:"file"`
+		Format    string `json:"format"`
+		Date      string `json:"date"`
+		TxType    string `json:"transactionType"`
+		FromBlock uint64 `json:"fromBlock"`
+		ToBlock   uint64 `json:"toBlock"`
+		Events    int    `json:"events"`
+	}
+
+	// parquetRecord flattens the event envelope, the payload is kept as its JSON encoding
+	parquetRecord struct {
+		EventID         string `parquet:"name=event_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+		SchemaVersion   int32  `parquet:"name=schema_version, type=INT32"`
+		Block           int64  `parquet:"name=block, type=INT64"`
+		BlockHash       string `parquet:"name=block_hash, type=BYTE_ARRAY, convertedtype=UTF8"`
+		ContractAddress string `parquet:"name=contract_address, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Success         bool   `parquet:"name=success, type=BOOLEAN"`
+		Timestamp       int64  `parquet:"name=timestamp, type=INT64"`
+		TxHash          string `parquet:"name=transaction_hash, type=BYTE_ARRAY, convertedtype=UTF8"`
+		TxIndex         int32  `parquet:"name=transaction_index, type=INT32"`
+		TxType          string `parquet:"name=transaction_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+		LogIndex        int32  `parquet:"name=log_index, type=INT32"`
+		// CallIndex is null for events derived from logs
+		CallIndex    *int32 `parquet:"name=call_index, type=INT32, repetitiontype=OPTIONAL"`
+		UserOpSender string `parquet:"name=user_op_sender, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Payload      string `parquet:"name=payload, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Tx           string `parquet:"name=tx, type=BYTE_ARRAY, convertedtype=UTF8"`
+	}
+)
+
+const (
+	archiveManifestName   = "manifest.jsonl"
+	archivePartialSuffix  = ".part"
+	archiveParquetWorkers = 1
+
+	defaultArchiveMaxEvents      = 100_000
+	defaultArchiveRotateInterval = time.Hour
+	defaultArchiveMaxOpenFiles   = 64
+	archiveFlushInterval         = 10 * time.Second
+)
+
+func NewArchivePub(o ArchiveOpts) (Pub, error) {
+	if o.Format == "" {
+		o.Format = ArchiveFormatJSONL
+	}
+	if o.Format != ArchiveFormatJSONL && o.Format != ArchiveFormatParquet {
+		return nil, fmt.Errorf("unsupported archive format %s", o.Format)
+	}
+	if o.MaxEvents <= 0 {
+		o.MaxEvents = defaultArchiveMaxEvents
+	}
+	if o.RotateInterval <= 0 {
+		o.RotateInterval = defaultArchiveRotateInterval
+	}
+	if o.MaxOpenFiles <= 0 {
+		o.MaxOpenFiles = defaultArchiveMaxOpenFiles
+	}
+
+	if err := os.MkdirAll(o.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	manifest, err := os.OpenFile(filepath.Join(o.Dir, archiveManifestName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &archivePub{
+		dir:            o.Dir,
+		format:         o.Format,
+		maxEvents:      o.MaxEvents,
+		rotateInterval: o.RotateInterval,
+		maxOpenFiles:   o.MaxOpenFiles,
+		logg:           o.Logg,
+		files:          make(map[archivePartition]*archiveFile),
+		manifest:       manifest,
+		stopCh:         make(chan struct{}),
+	}
+
+	if err := p.recoverPartialFiles(); err != nil {
+		manifest.Close()
+		return nil, err
+	}
+
+	p.wg.Add(1)
+	go p.rotateExpired()
+
+	return p, nil
+}
+
+func (p *archivePub) Close() {
+	close(p.stopCh)
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for partition, f := range p.files {
+		if err := p.finalize(f); err != nil {
+			p.logg.Error("could not finalize archive file", "file", f.name, "error", err)
+		}
+		delete(p.files, partition)
+	}
+
+	if err := p.manifest.Close(); err != nil {
+		p.logg.Error("could not close archive manifest", "error", err)
+	}
+}
+
+// Send appends the event to the file of its date and event type, the date is taken from the block timestamp
+func (p *archivePub) Send(_ context.Context, payload event.Event) error {
+	partition := archivePartition{
+		date:   time.Unix(int64(payload.Timestamp), 0).UTC().Format(time.DateOnly),
+		txType: payload.TxType,
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, ok := p.files[partition]
+	if !ok {
+		if len(p.files) >= p.maxOpenFiles {
+			if err := p.evictLeastRecent(); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		f, err = p.open(partition)
+		if err != nil {
+			return err
+		}
+		p.files[partition] = f
+	}
+
+	if err := p.write(f, payload); err != nil {
+		return err
+	}
+
+	if f.manifest.Events >= p.maxEvents {
+		delete(p.files, partition)
+		return p.finalize(f)
+	}
+
+	return nil
+}
+
+// evictLeastRecent completes the file written longest ago, e.g. the partitions of a date that has passed
+func (p *archivePub) evictLeastRecent() error {
+	var (
+		oldest     archivePartition
+		oldestFile *archiveFile
+	)
+	for partition, f := range p.files {
+		if oldestFile == nil || f.lastWrite < oldestFile.lastWrite {
+			oldest, oldestFile = partition, f
+		}
+	}
+	if oldestFile == nil {
+		return nil
+	}
+
+	delete(p.files, oldest)
+	return p.finalize(oldestFile)
+}
+
+func (p *archivePub) open(partition archivePartition) (*archiveFile, error) {
+	relDir := filepath.Join("date="+partition.date, "type="+partition.txType)
+	if err := os.MkdirAll(filepath.Join(p.dir, relDir), 0755); err != nil {
+		return nil, err
+	}
+
+	openedAt := time.Now()
+	name := filepath.Join(relDir, fmt.Sprintf("%d.%s", openedAt.UnixNano(), p.extension()))
+
+	file, err := os.Create(filepath.Join(p.dir, name+archivePartialSuffix))
+	if err != nil {
+		return nil, err
+	}
+
+	f := &archiveFile{
+		name:     name,
+		file:     file,
+		openedAt: openedAt,
+		manifest: ArchiveManifestEntry{
+			File:   filepath.ToSlash(name),
+			Format: p.format,
+			Date:   partition.date,
+			TxType: partition.txType,
+		},
+	}
+
+	if p.format == ArchiveFormatParquet {
+		f.parquet, err = writer.NewParquetWriterFromWriter(file, new(parquetRecord), archiveParquetWorkers)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		f.parquet.CompressionType = parquet.CompressionCodec_SNAPPY
+	} else {
+		f.jsonl = gzip.NewWriter(file)
+	}
+
+	return f, nil
+}
+
+func (p *archivePub) write(f *archiveFile, payload event.Event) error {
+	if f.parquet != nil {
+		record, err := toParquetRecord(payload)
+		if err != nil {
+			return err
+		}
+		if err := f.parquet.Write(record); err != nil {
+			return err
+		}
+	} else {
+		// Flushing once per block keeps the gzip compression effective while completed blocks stay recoverable after a crash
+		if f.dirty && payload.Block != f.lastBlock {
+			if err := p.flush(f); err != nil {
+				return err
+			}
+		}
+
+		data, err := payload.Serialize()
+		if err != nil {
+			return err
+		}
+		if _, err := f.jsonl.Write(append(data, '\n')); err != nil {
+			return err
+		}
+		f.lastBlock = payload.Block
+		f.dirty = true
+	}
+	p.writes++
+	f.lastWrite = p.writes
+
+	if f.manifest.Events == 0 || payload.Block < f.manifest.FromBlock {
+		f.manifest.FromBlock = payload.Block
+	}
+	if payload.Block > f.manifest.ToBlock {
+		f.manifest.ToBlock = payload.Block
+	}
+	f.manifest.Events++
+
+	return nil
+}
+
+func (p *archivePub) flush(f *archiveFile) error {
+	if err := f.jsonl.Flush(); err != nil {
+		return err
+	}
+	f.dirty = false
+
+	return nil
+}
+
+// finalize completes the file, renames it to its final name and records it in the manifest
+func (p *archivePub) finalize(f *archiveFile) error {
+	if f.parquet != nil {
+		if err := f.parquet.WriteStop(); err != nil {
+			f.file.Close()
+			return err
+		}
+	} else if err := f.jsonl.Close(); err != nil {
+		f.file.Close()
+		return err
+	}
+
+	if err := f.file.Sync(); err != nil {
+		f.file.Close()
+		return err
+	}
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(p.dir, f.name)
+	if err := os.Rename(path+archivePartialSuffix, path); err != nil {
+		return err
+	}
+
+	return p.appendManifest(f.manifest)
+}
+
+func (p *archivePub) appendManifest(entry ArchiveManifestEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if _, err := p.manifest.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return p.manifest.Sync()
+}
+
+func (p *archivePub) rotateExpired() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(min(p.rotateInterval/4, archiveFlushInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			for partition, f := range p.files {
+				if time.Since(f.openedAt) < p.rotateInterval {
+					// The last block of an idle partition would otherwise stay buffered until its next event
+					if f.dirty {
+						if err := p.flush(f); err != nil {
+							p.logg.Error("could not flush archive file", "file", f.name, "error", err)
+						}
+					}
+					continue
+				}
+
+				delete(p.files, partition)
+				if err := p.finalize(f); err != nil {
+					p.logg.Error("could not rotate archive file", "file", f.name, "error", err)
+				}
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// recoverPartialFiles completes JSONL files left open by a crash, partial Parquet files lack their footer and are set aside
+func (p *archivePub) recoverPartialFiles() error {
+	return filepath.WalkDir(p.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, archivePartialSuffix) {
+			return err
+		}
+
+		name := strings.TrimSuffix(path, archivePartialSuffix)
+		if !strings.HasSuffix(name, "."+ArchiveFormatJSONL+".gz") {
+			p.logg.Warn("setting aside incomplete archive file", "file", path)
+			return os.Rename(path, name+".incomplete")
+		}
+
+		entry, err := p.recoverJSONL(path, name)
+		if err != nil {
+			return err
+		}
+		if entry.Events == 0 {
+			return os.Remove(name)
+		}
+		p.logg.Info("recovered archive file", "file", name, "events", entry.Events)
+
+		return p.appendManifest(entry)
+	})
+}
+
+func (p *archivePub) recoverJSONL(partialPath string, path string) (ArchiveManifestEntry, error) {
+	rel, err := filepath.Rel(p.dir, path)
+	if err != nil {
+		return ArchiveManifestEntry{}, err
+	}
+
+	entry := ArchiveManifestEntry{
+		File:   filepath.ToSlash(rel),
+		Format: ArchiveFormatJSONL,
+	}
+
+	src, err := os.Open(partialPath)
+	if err != nil {
+		return entry, err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return entry, err
+	}
+	defer dst.Close()
+
+	gzWriter := gzip.NewWriter(dst)
+
+	gzReader, err := gzip.NewReader(src)
+	if err == nil {
+		scanner := bufio.NewScanner(gzReader)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+		// The stream of a crashed writer ends without a trailer, every complete line before that is kept
+		for scanner.Scan() {
+			var ev event.Event
+			if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+				break
+			}
+
+			if _, err := gzWriter.Write(append(scanner.Bytes(), '\n')); err != nil {
+				return entry, err
+			}
+
+			if entry.Events == 0 || ev.Block < entry.FromBlock {
+				entry.FromBlock = ev.Block
+			}
+			if ev.Block > entry.ToBlock {
+				entry.ToBlock = ev.Block
+			}
+			entry.Date = time.Unix(int64(ev.Timestamp), 0).UTC().Format(time.DateOnly)
+			entry.TxType = ev.TxType
+			entry.Events++
+		}
+	} else if !errors.Is(err, io.EOF) {
+		return entry, err
+	}
+
+	if err := gzWriter.Close(); err != nil {
+		return entry, err
+	}
+	if err := dst.Sync(); err != nil {
+		return entry, err
+	}
+
+	return entry, os.Remove(partialPath)
+}
+
+func (p *archivePub) extension() string {
+	if p.format == ArchiveFormatParquet {
+		return ArchiveFormatParquet
+	}
+
+	return ArchiveFormatJSONL + ".gz"
+}
+
+func toParquetRecord(payload event.Event) (*parquetRecord, error) {
+	payloadJSON, err := json.Marshal(payload.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var txJSON []byte
+	if payload.Tx != nil {
+		txJSON, err = json.Marshal(payload.Tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Events are only stamped with the schema version when serialized, replayed events keep the version they were recorded with
+	schemaVersion := payload.SchemaVersion
+	if schemaVersion == 0 {
+		schemaVersion = event.SchemaVersion
+	}
+
+	var callIndex *int32
+	if payload.CallIndex != nil {
+		v := int32(*payload.CallIndex)
+		callIndex = &v
+	}
+
+	return &parquetRecord{
+		EventID:         payload.ID(),
+		SchemaVersion:   int32(schemaVersion),
+		Block:           int64(payload.Block),
+		BlockHash:       payload.BlockHash,
+		ContractAddress: payload.ContractAddress,
+		Success:         payload.Success,
+		Timestamp:       int64(payload.Timestamp),
+		TxHash:          payload.TxHash,
+		TxIndex:         int32(payload.TxIndex),
+		TxType:          payload.TxType,
+		LogIndex:        int32(payload.Index),
+		CallIndex:       callIndex,
+		UserOpSender:    payload.UserOpSender,
+		Payload:         string(payloadJSON),
+		Tx:              string(txJSON),
+	}, nil
+}
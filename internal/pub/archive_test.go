@@ -0,0 +1,221 @@
+package pub
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+)
+
+// 2024-01-01T00:00:00Z
+const testArchiveTimestamp = 1_704_067_200
+
+func testArchiveEvent(block uint64, txType string) event.Event {
+	return event.Event{
+		Block:     block,
+		Timestamp: testArchiveTimestamp,
+		TxHash:    "0x01",
+		TxType:    txType,
+		Payload:   &event.TokenTransferPayload{Value: "1"},
+	}
+}
+
+func readManifest(t *testing.T, dir string) []ArchiveManifestEntry {
+	data, err := os.ReadFile(filepath.Join(dir, archiveManifestName))
+	require.NoError(t, err)
+
+	var entries []ArchiveManifestEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var entry ArchiveManifestEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func readArchive(t *testing.T, path string) []event.Event {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	var events []event.Event
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		ev, err := event.Deserialize(scanner.Bytes())
+		require.NoError(t, err)
+		events = append(events, ev)
+	}
+	require.NoError(t, scanner.Err())
+
+	return events
+}
+
+func TestArchivePub_RotateAndManifest(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := NewArchivePub(ArchiveOpts{
+		Dir:       dir,
+		MaxEvents: 2,
+		Logg:      slog.Default(),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, p.Send(ctx, testArchiveEvent(11, "TOKEN_TRANSFER")))
+	require.NoError(t, p.Send(ctx, testArchiveEvent(10, "TOKEN_TRANSFER")))
+	require.NoError(t, p.Send(ctx, testArchiveEvent(12, "TOKEN_TRANSFER")))
+	require.NoError(t, p.Send(ctx, testArchiveEvent(12, "TOKEN_MINT")))
+	p.Close()
+
+	manifest := readManifest(t, dir)
+	require.Len(t, manifest, 3)
+
+	require.Equal(t, "2024-01-01", manifest[0].Date)
+	require.Equal(t, "TOKEN_TRANSFER", manifest[0].TxType)
+	require.Equal(t, uint64(10), manifest[0].FromBlock)
+	require.Equal(t, uint64(11), manifest[0].ToBlock)
+	require.Equal(t, 2, manifest[0].Events)
+	require.Contains(t, manifest[0].File, "date=2024-01-01/type=TOKEN_TRANSFER/")
+
+	var total int
+	for _, entry := range manifest {
+		total += len(readArchive(t, filepath.Join(dir, entry.File)))
+	}
+	require.Equal(t, 4, total)
+}
+
+func TestArchivePub_RecoverPartialFile(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := NewArchivePub(ArchiveOpts{Dir: dir, Logg: slog.Default()})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, p.Send(ctx, testArchiveEvent(10, "TOKEN_TRANSFER")))
+	require.NoError(t, p.Send(ctx, testArchiveEvent(10, "TOKEN_TRANSFER")))
+	require.NoError(t, p.Send(ctx, testArchiveEvent(11, "TOKEN_TRANSFER")))
+
+	// Simulate a crash, the gzip stream is flushed up to block 10 but never closed
+	archive := p.(*archivePub)
+	for _, f := range archive.files {
+		require.NoError(t, f.file.Close())
+	}
+	close(archive.stopCh)
+	archive.wg.Wait()
+	archive.manifest.Close()
+
+	p, err = NewArchivePub(ArchiveOpts{Dir: dir, Logg: slog.Default()})
+	require.NoError(t, err)
+	p.Close()
+
+	manifest := readManifest(t, dir)
+	require.Len(t, manifest, 1)
+	require.Equal(t, uint64(10), manifest[0].FromBlock)
+	require.Equal(t, uint64(10), manifest[0].ToBlock)
+	require.Len(t, readArchive(t, filepath.Join(dir, manifest[0].File)), 2)
+}
+
+func TestArchivePub_EvictsLeastRecentPartition(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := NewArchivePub(ArchiveOpts{Dir: dir, MaxOpenFiles: 2, Logg: slog.Default()})
+	require.NoError(t, err)
+
+	nextDay := testArchiveEvent(20, "TOKEN_TRANSFER")
+	nextDay.Timestamp += 24 * 60 * 60
+
+	ctx := context.Background()
+	require.NoError(t, p.Send(ctx, testArchiveEvent(10, "TOKEN_TRANSFER")))
+	require.NoError(t, p.Send(ctx, testArchiveEvent(11, "TOKEN_MINT")))
+	require.NoError(t, p.Send(ctx, nextDay))
+
+	// The partition written longest ago is completed to make room for the new date
+	manifest := readManifest(t, dir)
+	require.Len(t, manifest, 1)
+	require.Equal(t, "2024-01-01", manifest[0].Date)
+	require.Equal(t, "TOKEN_TRANSFER", manifest[0].TxType)
+	require.Len(t, p.(*archivePub).files, 2)
+
+	p.Close()
+	require.Len(t, readManifest(t, dir), 3)
+}
+
+// parquetBuffer reads a parquet file from memory, the reader opens a copy per column
+type parquetBuffer struct {
+	*bytes.Reader
+	data []byte
+}
+
+func newParquetBuffer(data []byte) parquetBuffer {
+	return parquetBuffer{Reader: bytes.NewReader(data), data: data}
+}
+
+func (b parquetBuffer) Write([]byte) (int, error)                 { return 0, os.ErrPermission }
+func (b parquetBuffer) Close() error                              { return nil }
+func (b parquetBuffer) Open(string) (source.ParquetFile, error)   { return newParquetBuffer(b.data), nil }
+func (b parquetBuffer) Create(string) (source.ParquetFile, error) { return nil, os.ErrPermission }
+
+func TestArchivePub_Parquet(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := NewArchivePub(ArchiveOpts{Dir: dir, Format: ArchiveFormatParquet, RotateInterval: time.Hour, Logg: slog.Default()})
+	require.NoError(t, err)
+
+	var (
+		logEvent  = testArchiveEvent(10, "TOKEN_TRANSFER")
+		callEvent = testArchiveEvent(10, "TOKEN_TRANSFER")
+		callIndex = uint(2)
+	)
+	logEvent.Index = 3
+	logEvent.UserOpSender = "0x00000000000000000000000000000000000000aa"
+	callEvent.Success = true
+	callEvent.CallIndex = &callIndex
+	callEvent.SchemaVersion = 3
+
+	require.NoError(t, p.Send(context.Background(), logEvent))
+	require.NoError(t, p.Send(context.Background(), callEvent))
+	p.Close()
+
+	manifest := readManifest(t, dir)
+	require.Len(t, manifest, 1)
+
+	data, err := os.ReadFile(filepath.Join(dir, manifest[0].File))
+	require.NoError(t, err)
+	require.Equal(t, "PAR1", string(data[:4]))
+	require.Equal(t, "PAR1", string(data[len(data)-4:]))
+
+	pr, err := reader.NewParquetReader(newParquetBuffer(data), new(parquetRecord), 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+
+	records := make([]parquetRecord, pr.GetNumRows())
+	require.NoError(t, pr.Read(&records))
+	require.Len(t, records, 2)
+
+	require.Equal(t, "0x01:3", records[0].EventID)
+	require.Equal(t, int32(event.SchemaVersion), records[0].SchemaVersion)
+	require.Equal(t, logEvent.UserOpSender, records[0].UserOpSender)
+	require.Nil(t, records[0].CallIndex)
+
+	require.Equal(t, "0x01:call:2", records[1].EventID)
+	require.Equal(t, int32(3), records[1].SchemaVersion)
+	require.Empty(t, records[1].UserOpSender)
+	require.NotNil(t, records[1].CallIndex)
+	require.Equal(t, int32(2), *records[1].CallIndex)
+}
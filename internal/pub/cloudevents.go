@@ -0,0 +1,99 @@
+package pub
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/nats-io/nats.go"
+)
+
+// CloudEvents 1.0 content modes, see https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/nats-protocol-binding.md
+const (
+	CloudEventsStructured = "structured"
+	CloudEventsBinary     = "binary"
+
+	cloudEventsSpecVersion  = "1.0"
+	cloudEventsContentType  = "application/cloudevents+json"
+	cloudEventsHeaderPrefix = "ce-"
+)
+
+type (
+	cloudEventsEncoder struct {
+		mode        string
+		chainID     int64
+		contentType string
+	}
+
+	cloudEvent struct {
+		SpecVersion     string          `json:"specversion"`
+		ID              string          `json:"id"`
+		Source          string          `json:"source"`
+		Type            string          `json:"type"`
+		Time            string          `json:"time"`
+		Subject         string          `json:"subject,omitempty"`
+		DataContentType string          `json:"datacontenttype"`
+		Data            json.RawMessage `json:"data,omitempty"`
+		DataBase64      []byte          `json:"data_base64,omitempty"`
+	}
+)
+
+func newCloudEventsEncoder(mode string, chainID int64, contentType string) (*cloudEventsEncoder, error) {
+	if mode != CloudEventsStructured && mode != CloudEventsBinary {
+		return nil, fmt.Errorf("unsupported cloudevents mode %s", mode)
+	}
+
+	return &cloudEventsEncoder{
+		mode:        mode,
+		chainID:     chainID,
+		contentType: contentType,
+	}, nil
+}
+
+// encode wraps the serialized event, the id reuses the event ID which is derived from the tx hash and log index
+func (c *cloudEventsEncoder) encode(subject string, ev event.Event, data []byte) (*nats.Msg, error) {
+	ce := cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              ev.ID(),
+		Source:          fmt.Sprintf("/chains/%d/contracts/%s", c.chainID, ev.ContractAddress),
+		Type:            ev.TxType,
+		Time:            time.Unix(int64(ev.Timestamp), 0).UTC().Format(time.RFC3339),
+		Subject:         ev.TxHash,
+		DataContentType: c.contentType,
+	}
+
+	msg := nats.NewMsg(subject)
+
+	if c.mode == CloudEventsBinary {
+		msg.Data = data
+		msg.Header.Set(event.ContentTypeHeader, c.contentType)
+		msg.Header.Set(cloudEventsHeaderPrefix+"specversion", ce.SpecVersion)
+		msg.Header.Set(cloudEventsHeaderPrefix+"id", ce.ID)
+		msg.Header.Set(cloudEventsHeaderPrefix+"source", ce.Source)
+		msg.Header.Set(cloudEventsHeaderPrefix+"type", ce.Type)
+		msg.Header.Set(cloudEventsHeaderPrefix+"time", ce.Time)
+		if ce.Subject != "" {
+			msg.Header.Set(cloudEventsHeaderPrefix+"subject", ce.Subject)
+		}
+
+		return msg, nil
+	}
+
+	// Structured mode can only inline JSON data, other formats are carried base64 encoded
+	if c.contentType == event.ContentTypeJSON {
+		ce.Data = data
+	} else {
+		ce.DataBase64 = data
+	}
+
+	structured, err := json.Marshal(ce)
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Data = structured
+	msg.Header.Set(event.ContentTypeHeader, cloudEventsContentType)
+
+	return msg, nil
+}
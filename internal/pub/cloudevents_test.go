@@ -0,0 +1,99 @@
+package pub
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+var testCloudEvent = event.Event{
+	Block:           100,
+	ContractAddress: "0x0000000000000000000000000000000000000c01",
+	Success:         true,
+	Timestamp:       1700000000,
+	TxHash:          "0x01",
+	TxType:          "TOKEN_TRANSFER",
+	Index:           2,
+	Payload:         &event.TokenTransferPayload{From: "0xa", To: "0xb", Value: "10"},
+}
+
+func TestCloudEvents_Structured(t *testing.T) {
+	encoder, err := newCloudEventsEncoder(CloudEventsStructured, 42220, event.ContentTypeJSON)
+	require.NoError(t, err)
+
+	data, err := testCloudEvent.Serialize()
+	require.NoError(t, err)
+
+	msg, err := encoder.encode("TRACKER.TOKEN_TRANSFER", testCloudEvent, data)
+	require.NoError(t, err)
+	require.Equal(t, "TRACKER.TOKEN_TRANSFER", msg.Subject)
+	require.Equal(t, cloudEventsContentType, msg.Header.Get(event.ContentTypeHeader))
+	require.Empty(t, msg.Header.Get("ce-id"))
+
+	var ce cloudEvent
+	require.NoError(t, json.Unmarshal(msg.Data, &ce))
+	require.Equal(t, cloudEvent{
+		SpecVersion:     "1.0",
+		ID:              "0x01:2",
+		Source:          "/chains/42220/contracts/0x0000000000000000000000000000000000000c01",
+		Type:            "TOKEN_TRANSFER",
+		Time:            "2023-11-14T22:13:20Z",
+		Subject:         "0x01",
+		DataContentType: event.ContentTypeJSON,
+		Data:            data,
+	}, ce)
+}
+
+func TestCloudEvents_StructuredProtobuf(t *testing.T) {
+	encoder, err := newCloudEventsEncoder(CloudEventsStructured, 42220, event.ContentTypeProtobuf)
+	require.NoError(t, err)
+
+	data, err := testCloudEvent.SerializeAs(event.ContentTypeProtobuf)
+	require.NoError(t, err)
+
+	msg, err := encoder.encode("TRACKER.TOKEN_TRANSFER", testCloudEvent, data)
+	require.NoError(t, err)
+
+	var ce cloudEvent
+	require.NoError(t, json.Unmarshal(msg.Data, &ce))
+	require.Equal(t, event.ContentTypeProtobuf, ce.DataContentType)
+	require.Nil(t, ce.Data)
+	require.Equal(t, data, ce.DataBase64)
+
+	decoded, err := event.DeserializeAs(ce.DataBase64, ce.DataContentType)
+	require.NoError(t, err)
+	require.Equal(t, testCloudEvent.Payload, decoded.Payload)
+}
+
+func TestCloudEvents_Binary(t *testing.T) {
+	encoder, err := newCloudEventsEncoder(CloudEventsBinary, 42220, event.ContentTypeJSON)
+	require.NoError(t, err)
+
+	data, err := testCloudEvent.Serialize()
+	require.NoError(t, err)
+
+	msg, err := encoder.encode("TRACKER.TOKEN_TRANSFER", testCloudEvent, data)
+	require.NoError(t, err)
+	require.Equal(t, data, msg.Data)
+	require.Equal(t, event.ContentTypeJSON, msg.Header.Get(event.ContentTypeHeader))
+	require.Equal(t, "1.0", msg.Header.Get("ce-specversion"))
+	require.Equal(t, "0x01:2", msg.Header.Get("ce-id"))
+	require.Equal(t, "/chains/42220/contracts/0x0000000000000000000000000000000000000c01", msg.Header.Get("ce-source"))
+	require.Equal(t, "TOKEN_TRANSFER", msg.Header.Get("ce-type"))
+	require.Equal(t, "2023-11-14T22:13:20Z", msg.Header.Get("ce-time"))
+	require.Equal(t, "0x01", msg.Header.Get("ce-subject"))
+
+	// Block markers have no transaction hash and therefore no subject
+	marker := event.Event{BlockHash: "0xb1", Timestamp: 1700000000, TxType: "BLOCK_PROCESSED", Payload: &event.BlockProcessedPayload{}}
+	msg, err = encoder.encode("TRACKER.BLOCK_PROCESSED", marker, data)
+	require.NoError(t, err)
+	require.Equal(t, "0xb1:BLOCK_PROCESSED", msg.Header.Get("ce-id"))
+	require.NotContains(t, msg.Header, "ce-subject")
+}
+
+func TestNewCloudEventsEncoder_RejectsUnknownMode(t *testing.T) {
+	_, err := newCloudEventsEncoder("batched", 42220, event.ContentTypeJSON)
+	require.Error(t, err)
+}
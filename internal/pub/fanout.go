@@ -0,0 +1,229 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+)
+
+// Failure policies of a fan-out sink
+const (
+	// PolicyBlock sends synchronously, a failure fails the whole Send so the block is reprocessed
+	PolicyBlock = "block"
+	// PolicyDrop sends from a bounded queue, events are dropped and counted when the queue is full or the send fails
+	PolicyDrop = "drop"
+	// PolicyBuffer sends from a bounded queue and retries failed sends, Send waits a bounded time for room when the queue is full
+	PolicyBuffer = "buffer"
+)
+
+type (
+	FanoutSink struct {
+		Name   string
+		Pub    Pub
+		Policy string
+		// BufferSize bounds the queue of drop and buffer sinks
+		BufferSize int
+		// MaxRetries caps the retries of a buffered event before it is dropped
+		MaxRetries int
+		// BufferWait bounds how long Send waits for room in a full buffer queue before the event is dropped
+		BufferWait time.Duration
+	}
+
+	FanoutOpts struct {
+		Sinks []FanoutSink
+		Logg  *slog.Logger
+	}
+
+	fanoutPub struct {
+		sinks []*fanoutSink
+		logg  *slog.Logger
+		wg    sync.WaitGroup
+	}
+
+	fanoutSink struct {
+		FanoutSink
+		queue   chan event.Event
+		stopCh  chan struct{}
+		dropped *metrics.Counter
+		failed  *metrics.Counter
+	}
+)
+
+const (
+	defaultFanoutBufferSize   = 4096
+	defaultFanoutMaxRetries   = 30
+	defaultFanoutBufferWait   = 5 * time.Second
+	fanoutBufferRetryInterval = time.Second
+)
+
+func NewFanoutPub(o FanoutOpts) (Pub, error) {
+	p := &fanoutPub{
+		logg: o.Logg,
+	}
+
+	for _, s := range o.Sinks {
+		if s.Policy == "" {
+			s.Policy = PolicyBlock
+		}
+		if s.BufferSize <= 0 {
+			s.BufferSize = defaultFanoutBufferSize
+		}
+		if s.MaxRetries <= 0 {
+			s.MaxRetries = defaultFanoutMaxRetries
+		}
+		if s.BufferWait <= 0 {
+			s.BufferWait = defaultFanoutBufferWait
+		}
+
+		sink := &fanoutSink{
+			FanoutSink: s,
+			dropped:    metrics.GetOrCreateCounter(fmt.Sprintf(`fanout_dropped_total{sink=%q}`, s.Name)),
+			failed:     metrics.GetOrCreateCounter(fmt.Sprintf(`fanout_failed_total{sink=%q}`, s.Name)),
+		}
+
+		switch s.Policy {
+		case PolicyBlock:
+		case PolicyDrop, PolicyBuffer:
+			sink.queue = make(chan event.Event, s.BufferSize)
+			sink.stopCh = make(chan struct{})
+			metrics.GetOrCreateGauge(fmt.Sprintf(`fanout_queue_size{sink=%q}`, s.Name), func() float64 {
+				return float64(len(sink.queue))
+			})
+
+			p.wg.Add(1)
+			go p.drain(sink)
+		default:
+			return nil, fmt.Errorf("unsupported failure policy %s for sink %s", s.Policy, s.Name)
+		}
+
+		p.sinks = append(p.sinks, sink)
+	}
+
+	return p, nil
+}
+
+// Close flushes queued events and closes every sink
+func (p *fanoutPub) Close() {
+	for _, s := range p.sinks {
+		if s.queue != nil {
+			close(s.stopCh)
+			close(s.queue)
+		}
+	}
+	p.wg.Wait()
+
+	for _, s := range p.sinks {
+		s.Pub.Close()
+	}
+}
+
+// Send only queues the event once every block sink accepted it, a failed FlushBlock can still requeue it so queued sinks are at-least-once
+func (p *fanoutPub) Send(ctx context.Context, payload event.Event) error {
+	for _, s := range p.sinks {
+		if s.Policy != PolicyBlock {
+			continue
+		}
+
+		if err := s.Pub.Send(ctx, payload); err != nil {
+			s.failed.Inc()
+			return fmt.Errorf("sink %s: %w", s.Name, err)
+		}
+	}
+
+	for _, s := range p.sinks {
+		switch s.Policy {
+		case PolicyDrop:
+			select {
+			case s.queue <- payload:
+			default:
+				s.dropped.Inc()
+			}
+		case PolicyBuffer:
+			if err := s.enqueue(ctx, payload); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// FlushBlock only flushes block sinks, queued sinks are flushed after every event
+func (p *fanoutPub) FlushBlock(ctx context.Context, block uint64) error {
+	for _, s := range p.sinks {
+		if s.Policy != PolicyBlock {
+			continue
+		}
+
+		if flusher, ok := s.Pub.(BlockFlusher); ok {
+			if err := flusher.FlushBlock(ctx, block); err != nil {
+				s.failed.Inc()
+				return fmt.Errorf("sink %s: %w", s.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *fanoutPub) drain(s *fanoutSink) {
+	defer p.wg.Done()
+
+	for payload := range s.queue {
+		for attempt := 0; ; attempt++ {
+			err := sendAndFlush(context.Background(), s.Pub, payload)
+			if err == nil {
+				break
+			}
+			s.failed.Inc()
+
+			if s.Policy == PolicyDrop || attempt >= s.MaxRetries || s.stopping() {
+				s.dropped.Inc()
+				p.logg.Warn("fanout sink dropped event", "sink", s.Name, "event", payload.ID(), "attempt", attempt, "error", err)
+				break
+			}
+
+			p.logg.Warn("fanout sink send failed, retrying", "sink", s.Name, "event", payload.ID(), "attempt", attempt, "error", err)
+			select {
+			case <-s.stopCh:
+			case <-time.After(fanoutBufferRetryInterval):
+			}
+		}
+	}
+}
+
+// enqueue waits at most BufferWait for room so that a sink which keeps failing cannot stall the block sinks
+func (s *fanoutSink) enqueue(ctx context.Context, payload event.Event) error {
+	select {
+	case s.queue <- payload:
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(s.BufferWait)
+	defer timer.Stop()
+
+	select {
+	case s.queue <- payload:
+	case <-timer.C:
+		s.dropped.Inc()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+func (s *fanoutSink) stopping() bool {
+	select {
+	case <-s.stopCh:
+		return true
+	default:
+		return false
+	}
+}
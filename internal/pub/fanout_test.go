@@ -0,0 +1,203 @@
+package pub
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+type testPub struct {
+	mu       sync.Mutex
+	sent     []string
+	failures int
+	closed   bool
+}
+
+func (p *testPub) Send(_ context.Context, payload event.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("unavailable")
+	}
+	p.sent = append(p.sent, payload.TxHash)
+	return nil
+}
+
+func (p *testPub) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+}
+
+func (p *testPub) events() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]string(nil), p.sent...)
+}
+
+func TestFanoutPub_Policies(t *testing.T) {
+	var (
+		primary  = &testPub{}
+		dropped  = &testPub{failures: 1}
+		buffered = &testPub{failures: 1}
+	)
+
+	p, err := NewFanoutPub(FanoutOpts{
+		Sinks: []FanoutSink{
+			{Name: "test_primary", Pub: primary, Policy: PolicyBlock},
+			{Name: "test_dropped", Pub: dropped, Policy: PolicyDrop},
+			{Name: "test_buffered", Pub: buffered, Policy: PolicyBuffer},
+		},
+		Logg: slog.Default(),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, p.Send(ctx, event.Event{TxHash: "0x01"}))
+	require.NoError(t, p.Send(ctx, event.Event{TxHash: "0x02"}))
+
+	require.Eventually(t, func() bool {
+		return len(buffered.events()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	p.Close()
+
+	require.Equal(t, []string{"0x01", "0x02"}, primary.events())
+	require.Equal(t, []string{"0x02"}, dropped.events())
+	require.Equal(t, []string{"0x01", "0x02"}, buffered.events())
+	require.True(t, primary.closed && dropped.closed && buffered.closed)
+
+	_, err = NewFanoutPub(FanoutOpts{Sinks: []FanoutSink{{Name: "test_invalid", Pub: primary, Policy: "retry"}}})
+	require.Error(t, err)
+}
+
+func TestFanoutPub_BlockFailure(t *testing.T) {
+	p, err := NewFanoutPub(FanoutOpts{
+		Sinks: []FanoutSink{
+			{Name: "test_failing", Pub: &testPub{failures: 1}, Policy: PolicyBlock},
+		},
+		Logg: slog.Default(),
+	})
+	require.NoError(t, err)
+	defer p.Close()
+
+	require.Error(t, p.Send(context.Background(), event.Event{TxHash: "0x01"}))
+	require.NoError(t, p.Send(context.Background(), event.Event{TxHash: "0x01"}))
+}
+
+func TestFanoutPub_BlockFailureSkipsQueuedSinks(t *testing.T) {
+	var (
+		buffered = &testPub{}
+		primary  = &testPub{failures: 1}
+	)
+
+	// The buffer sink is listed first, it must still only see events the block sink accepted
+	p, err := NewFanoutPub(FanoutOpts{
+		Sinks: []FanoutSink{
+			{Name: "test_queued", Pub: buffered, Policy: PolicyBuffer},
+			{Name: "test_failing_primary", Pub: primary, Policy: PolicyBlock},
+		},
+		Logg: slog.Default(),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.Error(t, p.Send(ctx, event.Event{TxHash: "0x01"}))
+	// The block is reprocessed
+	require.NoError(t, p.Send(ctx, event.Event{TxHash: "0x01"}))
+	p.Close()
+
+	require.Equal(t, []string{"0x01"}, primary.events())
+	require.Equal(t, []string{"0x01"}, buffered.events())
+}
+
+func TestFanoutPub_FailingBufferSinkDoesNotStallPrimary(t *testing.T) {
+	var (
+		primary  = &testPub{}
+		buffered = &testPub{failures: math.MaxInt}
+	)
+
+	p, err := NewFanoutPub(FanoutOpts{
+		Sinks: []FanoutSink{
+			{Name: "test_stalled_primary", Pub: primary, Policy: PolicyBlock},
+			{Name: "test_stalled_buffered", Pub: buffered, Policy: PolicyBuffer, BufferSize: 1, MaxRetries: 1, BufferWait: 10 * time.Millisecond},
+		},
+		Logg: slog.Default(),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	start := time.Now()
+	for _, txHash := range []string{"0x01", "0x02", "0x03", "0x04", "0x05"} {
+		require.NoError(t, p.Send(ctx, event.Event{TxHash: txHash}))
+	}
+	require.Less(t, time.Since(start), time.Second)
+	require.Equal(t, []string{"0x01", "0x02", "0x03", "0x04", "0x05"}, primary.events())
+
+	// Events that find the queue full are dropped, the queued ones once their retries are exhausted
+	dropped := metrics.GetOrCreateCounter(`fanout_dropped_total{sink="test_stalled_buffered"}`)
+	require.Eventually(t, func() bool {
+		return dropped.Get() >= 4
+	}, 5*time.Second, 10*time.Millisecond)
+	p.Close()
+
+	require.Equal(t, uint64(5), dropped.Get())
+	require.Empty(t, buffered.events())
+}
+
+type testFlushPub struct {
+	testPub
+	flushed []uint64
+}
+
+func (p *testFlushPub) FlushBlock(_ context.Context, block uint64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.flushed = append(p.flushed, block)
+	return nil
+}
+
+func (p *testFlushPub) flushedBlocks() []uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]uint64(nil), p.flushed...)
+}
+
+func TestFanoutPub_FlushBlock(t *testing.T) {
+	var (
+		primary  = &testFlushPub{}
+		buffered = &testFlushPub{}
+	)
+
+	p, err := NewFanoutPub(FanoutOpts{
+		Sinks: []FanoutSink{
+			{Name: "test_flush_primary", Pub: primary, Policy: PolicyBlock},
+			{Name: "test_flush_buffered", Pub: buffered, Policy: PolicyBuffer},
+		},
+		Logg: slog.Default(),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, p.Send(ctx, event.Event{Block: 10, TxHash: "0x01"}))
+	require.NoError(t, p.Send(ctx, event.Event{Block: 10, TxHash: "0x02"}))
+	require.NoError(t, p.(BlockFlusher).FlushBlock(ctx, 10))
+	p.Close()
+
+	require.Equal(t, []uint64{10}, primary.flushedBlocks())
+	// Queued sinks are flushed after every event
+	require.Equal(t, []uint64{10, 10}, buffered.flushedBlocks())
+}
@@ -2,11 +2,17 @@ package pub
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/VictoriaMetrics/metrics"
 	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/subject"
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 )
@@ -14,67 +20,255 @@ import (
 type (
 	JetStreamOpts struct {
 		Endpoint        string
+		Nats            NatsOpts
 		PersistDuration time.Duration
+		// StreamName defaults to TRACKER
+		StreamName string
+		// SubjectTemplate defaults to {stream}.{eventType}, see pkg/subject
+		SubjectTemplate string
+		Replicas        int
+		// Storage is file or memory, defaults to file
+		Storage string
+		// MaxBytes limits the stream size, unlimited when 0
+		MaxBytes int64
+		// DuplicateWindow defaults to 20 minutes
+		DuplicateWindow time.Duration
+		// ContentType selects the wire format of published events, defaults to JSON
+		ContentType string
+		// CloudEventsMode wraps events in a CloudEvents envelope, either structured or binary, disabled when empty
+		CloudEventsMode string
+		// AsyncMaxPending enables async publishing with at most this many unacknowledged events, Send blocks when the window is full
+		AsyncMaxPending int
+		// AsyncAckTimeout fails unacknowledged async publishes, defaults to 30 seconds
+		AsyncAckTimeout time.Duration
+		ChainID         int64
 		Logg            *slog.Logger
 	}
 
 	jetStreamPub struct {
-		js       jetstream.JetStream
-		natsConn *nats.Conn
+		js          jetstream.JetStream
+		natsConn    *nats.Conn
+		streamName  string
+		chainID     string
+		subjects    subject.Template
+		contentType string
+		cloudEvents *cloudEventsEncoder
+		async       bool
+		logg        *slog.Logger
+
+		// pending holds the acks of async publishes per block until the block is flushed
+		mu      sync.Mutex
+		pending map[uint64][]jetstream.PubAckFuture
 	}
 )
 
-const streamName string = "TRACKER"
+const (
+	defaultStreamName      = "TRACKER"
+	defaultReplicas        = 1
+	defaultDuplicateWindow = 20 * time.Minute
+	defaultAsyncAckTimeout = 30 * time.Second
+	asyncStallWait         = time.Second
+	asyncCloseTimeout      = 10 * time.Second
+)
 
-var streamSubjects = []string{
-	"TRACKER.*",
+var storageTypes = map[string]jetstream.StorageType{
+	"file":   jetstream.FileStorage,
+	"memory": jetstream.MemoryStorage,
 }
 
 func NewJetStreamPub(o JetStreamOpts) (Pub, error) {
-	natsConn, err := nats.Connect(o.Endpoint)
+	if o.StreamName == "" {
+		o.StreamName = defaultStreamName
+	}
+	if o.Replicas <= 0 {
+		o.Replicas = defaultReplicas
+	}
+	if o.Storage == "" {
+		o.Storage = "file"
+	}
+	if o.DuplicateWindow <= 0 {
+		o.DuplicateWindow = defaultDuplicateWindow
+	}
+	if o.MaxBytes <= 0 {
+		o.MaxBytes = -1
+	}
+	if o.AsyncAckTimeout <= 0 {
+		o.AsyncAckTimeout = defaultAsyncAckTimeout
+	}
+
+	storage, ok := storageTypes[o.Storage]
+	if !ok {
+		return nil, fmt.Errorf("unsupported stream storage %s", o.Storage)
+	}
+
+	subjects, err := subject.Parse(o.SubjectTemplate)
 	if err != nil {
 		return nil, err
 	}
 
-	js, err := jetstream.New(natsConn)
+	if o.ContentType == "" {
+		o.ContentType = event.ContentTypeJSON
+	}
+	if !event.ValidContentType(o.ContentType) {
+		return nil, fmt.Errorf("unsupported content type %s", o.ContentType)
+	}
+
+	var cloudEvents *cloudEventsEncoder
+	if o.CloudEventsMode != "" {
+		encoder, err := newCloudEventsEncoder(o.CloudEventsMode, o.ChainID, o.ContentType)
+		if err != nil {
+			return nil, err
+		}
+		cloudEvents = encoder
+	}
+
+	natsConn, err := natsConnect(o.Endpoint, o.Nats, o.Logg)
 	if err != nil {
 		return nil, err
 	}
 
+	var jsOpts []jetstream.JetStreamOpt
+	if o.AsyncMaxPending > 0 {
+		jsOpts = append(jsOpts,
+			jetstream.WithPublishAsyncMaxPending(o.AsyncMaxPending),
+			jetstream.WithPublishAsyncTimeout(o.AsyncAckTimeout),
+		)
+	}
+
+	js, err := jetstream.New(natsConn, jsOpts...)
+	if err != nil {
+		natsConn.Close()
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	js.CreateStream(ctx, jetstream.StreamConfig{
-		Name:       streamName,
-		Subjects:   streamSubjects,
+	if err := createOrUpdateStream(ctx, js, jetstream.StreamConfig{
+		Name:       o.StreamName,
+		Subjects:   []string{subjects.Format(subject.Values{Stream: o.StreamName})},
 		MaxAge:     o.PersistDuration,
-		Storage:    jetstream.FileStorage,
-		Duplicates: time.Minute * 20,
-	})
+		MaxBytes:   o.MaxBytes,
+		Storage:    storage,
+		Replicas:   o.Replicas,
+		Duplicates: o.DuplicateWindow,
+	}, o.Logg); err != nil {
+		natsConn.Close()
+		return nil, err
+	}
+
+	if o.AsyncMaxPending > 0 {
+		metrics.GetOrCreateGauge("jetstream_async_pending", func() float64 {
+			return float64(js.PublishAsyncPending())
+		})
+	}
 
 	return &jetStreamPub{
-		natsConn: natsConn,
-		js:       js,
+		natsConn:    natsConn,
+		js:          js,
+		streamName:  o.StreamName,
+		chainID:     strconv.FormatInt(o.ChainID, 10),
+		subjects:    subjects,
+		contentType: o.ContentType,
+		cloudEvents: cloudEvents,
+		async:       o.AsyncMaxPending > 0,
+		logg:        o.Logg,
+		pending:     make(map[uint64][]jetstream.PubAckFuture),
 	}, nil
 }
 
+// createOrUpdateStream only updates the settings managed by the tracker, other settings of an existing stream are kept
+func createOrUpdateStream(ctx context.Context, js jetstream.JetStream, want jetstream.StreamConfig, logg *slog.Logger) error {
+	stream, err := js.Stream(ctx, want.Name)
+	if errors.Is(err, jetstream.ErrStreamNotFound) {
+		if _, err := js.CreateStream(ctx, want); err != nil {
+			return fmt.Errorf("could not create stream %s: %w", want.Name, err)
+		}
+		logg.Info("created jetstream stream", "stream", want.Name, "subjects", want.Subjects)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not fetch stream %s: %w", want.Name, err)
+	}
+
+	current := stream.CachedInfo().Config
+	if current.Storage != want.Storage {
+		return fmt.Errorf("stream %s uses %s storage and cannot be changed to %s, recreate the stream or change the storage setting", want.Name, current.Storage, want.Storage)
+	}
+	if current.Retention != jetstream.LimitsPolicy {
+		return fmt.Errorf("stream %s uses the %s retention policy, the tracker requires the limits policy", want.Name, current.Retention)
+	}
+
+	updated := current
+	updated.Subjects = want.Subjects
+	updated.MaxAge = want.MaxAge
+	updated.MaxBytes = want.MaxBytes
+	updated.Replicas = want.Replicas
+	updated.Duplicates = want.Duplicates
+
+	if slices.Equal(current.Subjects, updated.Subjects) &&
+		current.MaxAge == updated.MaxAge &&
+		current.MaxBytes == updated.MaxBytes &&
+		current.Replicas == updated.Replicas &&
+		current.Duplicates == updated.Duplicates {
+		return nil
+	}
+
+	if _, err := js.UpdateStream(ctx, updated); err != nil {
+		return fmt.Errorf("stream %s exists with an incompatible config: %w", want.Name, err)
+	}
+	logg.Info("updated jetstream stream", "stream", want.Name, "subjects", want.Subjects)
+
+	return nil
+}
+
 func (p *jetStreamPub) Close() {
+	if p.async {
+		select {
+		case <-p.js.PublishAsyncComplete():
+		case <-time.After(asyncCloseTimeout):
+			p.logg.Warn("closing jetstream publisher with unacknowledged events", "pending", p.js.PublishAsyncPending())
+		}
+	}
+
 	if p.natsConn != nil {
 		p.natsConn.Close()
 	}
 }
 
 func (p *jetStreamPub) Send(ctx context.Context, payload event.Event) error {
-	data, err := payload.Serialize()
+	data, err := payload.SerializeAs(p.contentType)
 	if err != nil {
 		return err
 	}
 
-	_, err = p.js.Publish(
+	msgSubject := p.subjects.Format(subject.Values{
+		Stream:    p.streamName,
+		ChainID:   p.chainID,
+		EventType: payload.TxType,
+		Contract:  payload.ContractAddress,
+	})
+
+	var msg *nats.Msg
+	if p.cloudEvents != nil {
+		msg, err = p.cloudEvents.encode(msgSubject, payload, data)
+		if err != nil {
+			return err
+		}
+	} else {
+		msg = nats.NewMsg(msgSubject)
+		msg.Data = data
+		msg.Header.Set(event.ContentTypeHeader, p.contentType)
+	}
+
+	if p.async {
+		return p.publishAsync(ctx, payload.Block, msg, payload.ID())
+	}
+
+	_, err = p.js.PublishMsg(
 		ctx,
-		fmt.Sprintf("%s.%s", streamName, payload.TxType),
-		data,
-		jetstream.WithMsgID(fmt.Sprintf("%s:%d", payload.TxHash, payload.Index)),
+		msg,
+		jetstream.WithMsgID(payload.ID()),
 	)
 	if err != nil {
 		return err
@@ -82,3 +276,49 @@ func (p *jetStreamPub) Send(ctx context.Context, payload event.Event) error {
 
 	return nil
 }
+
+// publishAsync keeps retrying while the ack window is full, this blocks the worker and in turn the pool
+func (p *jetStreamPub) publishAsync(ctx context.Context, block uint64, msg *nats.Msg, msgID string) error {
+	for {
+		future, err := p.js.PublishMsgAsync(
+			msg,
+			jetstream.WithMsgID(msgID),
+			jetstream.WithStallWait(asyncStallWait),
+		)
+		if errors.Is(err, jetstream.ErrTooManyStalledMsgs) {
+			metrics.GetOrCreateCounter("jetstream_publish_stalls_total").Inc()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		p.mu.Lock()
+		p.pending[block] = append(p.pending[block], future)
+		p.mu.Unlock()
+
+		return nil
+	}
+}
+
+func (p *jetStreamPub) FlushBlock(ctx context.Context, block uint64) error {
+	p.mu.Lock()
+	futures := p.pending[block]
+	delete(p.pending, block)
+	p.mu.Unlock()
+
+	for _, future := range futures {
+		select {
+		case <-future.Ok():
+		case err := <-future.Err():
+			return fmt.Errorf("publish %s: %w", future.Msg().Header.Get(jetstream.MsgIDHeader), err)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package pub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+)
+
+// testAckFuture is resolved by the test instead of a server ack
+type testAckFuture struct {
+	msg *nats.Msg
+	ok  chan *jetstream.PubAck
+	err chan error
+}
+
+func newTestAckFuture(msgID string) *testAckFuture {
+	msg := nats.NewMsg("tracker.test")
+	msg.Header.Set(jetstream.MsgIDHeader, msgID)
+
+	return &testAckFuture{
+		msg: msg,
+		ok:  make(chan *jetstream.PubAck, 1),
+		err: make(chan error, 1),
+	}
+}
+
+func (f *testAckFuture) Ok() <-chan *jetstream.PubAck { return f.ok }
+func (f *testAckFuture) Err() <-chan error            { return f.err }
+func (f *testAckFuture) Msg() *nats.Msg               { return f.msg }
+
+func newTestAsyncJetStreamPub(pending map[uint64][]jetstream.PubAckFuture) *jetStreamPub {
+	return &jetStreamPub{
+		async:   true,
+		pending: pending,
+	}
+}
+
+func TestJetStreamPub_FlushBlockWaitsForAcks(t *testing.T) {
+	var (
+		first  = newTestAckFuture("0x01:0")
+		second = newTestAckFuture("0x01:1")
+		other  = newTestAckFuture("0x02:0")
+		p      = newTestAsyncJetStreamPub(map[uint64][]jetstream.PubAckFuture{
+			10: {first, second},
+			11: {other},
+		})
+	)
+
+	flushed := make(chan error, 1)
+	go func() {
+		flushed <- p.FlushBlock(context.Background(), 10)
+	}()
+
+	first.ok <- &jetstream.PubAck{}
+	select {
+	case <-flushed:
+		t.Fatal("block flushed before every ack arrived")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	second.ok <- &jetstream.PubAck{}
+	require.NoError(t, <-flushed)
+
+	// Only the acks of the flushed block are released
+	require.NotContains(t, p.pending, uint64(10))
+	require.Len(t, p.pending[11], 1)
+}
+
+func TestJetStreamPub_FlushBlockSurfacesFailedAck(t *testing.T) {
+	var (
+		acked  = newTestAckFuture("0x01:0")
+		failed = newTestAckFuture("0x01:1")
+		p      = newTestAsyncJetStreamPub(map[uint64][]jetstream.PubAckFuture{
+			10: {acked, failed},
+		})
+	)
+
+	acked.ok <- &jetstream.PubAck{}
+	failed.err <- nats.ErrTimeout
+
+	err := p.FlushBlock(context.Background(), 10)
+	require.ErrorIs(t, err, nats.ErrTimeout)
+	require.ErrorContains(t, err, "0x01:1")
+	// A failed block is reprocessed, its acks are not waited on again
+	require.Empty(t, p.pending)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.pending[11] = []jetstream.PubAckFuture{newTestAckFuture("0x02:0")}
+	require.ErrorIs(t, p.FlushBlock(ctx, 11), context.Canceled)
+}
@@ -0,0 +1,113 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type (
+	KafkaOpts struct {
+		Brokers []string
+		// TopicPrefix is prepended to the TxType to derive the topic, e.g. tracker.TOKEN_TRANSFER
+		TopicPrefix string
+		// Topics overrides the derived topic for individual TxTypes
+		Topics      map[string]string
+		ContentType string
+		Logg        *slog.Logger
+	}
+
+	kafkaPub struct {
+		client      *kgo.Client
+		topicPrefix string
+		topics      map[string]string
+		contentType string
+	}
+)
+
+const (
+	defaultKafkaTopicPrefix = "tracker"
+	defaultKafkaPingTimeout = 10 * time.Second
+	kafkaEventIDHeader      = "event-id"
+)
+
+func NewKafkaPub(o KafkaOpts) (Pub, error) {
+	if o.ContentType == "" {
+		o.ContentType = event.ContentTypeJSON
+	}
+	if !event.ValidContentType(o.ContentType) {
+		return nil, fmt.Errorf("unsupported content type %s", o.ContentType)
+	}
+	if o.TopicPrefix == "" {
+		o.TopicPrefix = defaultKafkaTopicPrefix
+	}
+
+	// The producer is idempotent by default which requires acks from all in-sync replicas,
+	// records of a contract share a key and therefore a partition so their order is preserved
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(o.Brokers...),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.RecordPartitioner(kgo.StickyKeyPartitioner(nil)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultKafkaPingTimeout)
+	defer cancel()
+
+	if err := client.Ping(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return &kafkaPub{
+		client:      client,
+		topicPrefix: o.TopicPrefix,
+		topics:      o.Topics,
+		contentType: o.ContentType,
+	}, nil
+}
+
+func (p *kafkaPub) Close() {
+	p.client.Close()
+}
+
+func (p *kafkaPub) Send(ctx context.Context, payload event.Event) error {
+	record, err := p.record(payload)
+	if err != nil {
+		return err
+	}
+
+	return p.client.ProduceSync(ctx, record).FirstErr()
+}
+
+func (p *kafkaPub) record(payload event.Event) (*kgo.Record, error) {
+	data, err := payload.SerializeAs(p.contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	// BLOCK_PROCESSED markers all carry the zero address, their topic is therefore written to a single partition in block order
+	return &kgo.Record{
+		Topic: p.topic(payload.TxType),
+		Key:   []byte(payload.ContractAddress),
+		Value: data,
+		Headers: []kgo.RecordHeader{
+			{Key: event.ContentTypeHeader, Value: []byte(p.contentType)},
+			{Key: kafkaEventIDHeader, Value: []byte(payload.ID())},
+		},
+	}, nil
+}
+
+func (p *kafkaPub) topic(txType string) string {
+	if topic, ok := p.topics[txType]; ok {
+		return topic
+	}
+
+	return fmt.Sprintf("%s.%s", p.topicPrefix, txType)
+}
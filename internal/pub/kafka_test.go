@@ -0,0 +1,68 @@
+package pub
+
+import (
+	"testing"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestKafkaPub_Record(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		topics      map[string]string
+		wantTopic   string
+	}{
+		{"DerivedTopic", event.ContentTypeJSON, nil, "tracker.TOKEN_TRANSFER"},
+		{"TopicOverride", event.ContentTypeJSON, map[string]string{"TOKEN_TRANSFER": "transfers"}, "transfers"},
+		{"Protobuf", event.ContentTypeProtobuf, map[string]string{"TOKEN_MINT": "mints"}, "tracker.TOKEN_TRANSFER"},
+	}
+
+	ev := event.Event{
+		ContractAddress: "0x0000000000000000000000000000000000000c01",
+		Success:         true,
+		TxHash:          "0x01",
+		TxType:          "TOKEN_TRANSFER",
+		Index:           4,
+		Payload:         &event.TokenTransferPayload{From: "0xa", To: "0xb", Value: "10"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &kafkaPub{topicPrefix: defaultKafkaTopicPrefix, topics: tc.topics, contentType: tc.contentType}
+
+			record, err := p.record(ev)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantTopic, record.Topic)
+			// Events of a contract share a key and therefore a partition
+			require.Equal(t, []byte(ev.ContractAddress), record.Key)
+			require.Equal(t, []kgo.RecordHeader{
+				{Key: event.ContentTypeHeader, Value: []byte(tc.contentType)},
+				{Key: kafkaEventIDHeader, Value: []byte("0x01:4")},
+			}, record.Headers)
+
+			decoded, err := event.DeserializeAs(record.Value, tc.contentType)
+			require.NoError(t, err)
+			require.Equal(t, ev.Payload, decoded.Payload)
+		})
+	}
+}
+
+func TestKafkaPub_RecordBlockMarker(t *testing.T) {
+	p := &kafkaPub{topicPrefix: defaultKafkaTopicPrefix, contentType: event.ContentTypeJSON}
+
+	record, err := p.record(event.Event{
+		Block:           10,
+		BlockHash:       "0xb1",
+		ContractAddress: "0x0000000000000000000000000000000000000000",
+		TxType:          "BLOCK_PROCESSED",
+		Payload:         &event.BlockProcessedPayload{EventCount: 2},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "tracker.BLOCK_PROCESSED", record.Topic)
+	// Every marker shares the key and therefore the partition
+	require.Equal(t, []byte("0x0000000000000000000000000000000000000000"), record.Key)
+	require.Equal(t, []byte("0xb1:BLOCK_PROCESSED"), record.Headers[1].Value)
+}
@@ -0,0 +1,127 @@
+package pub
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/nats-io/nats.go"
+)
+
+// NatsOpts configures authentication, TLS and connection health, empty fields keep the nats.go defaults
+type NatsOpts struct {
+	// Only one of CredsFile, NKeySeedFile, User and Token can be set
+	CredsFile    string
+	NKeySeedFile string
+	User         string
+	Password     string
+	Token        string
+
+	TLSCAFile   string
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// MaxReconnects is unlimited when negative
+	MaxReconnects       int
+	ReconnectWait       time.Duration
+	PingInterval        time.Duration
+	MaxPingsOutstanding int
+}
+
+const natsConnectionName = "eth-tracker"
+
+func natsConnect(endpoint string, o NatsOpts, logg *slog.Logger) (*nats.Conn, error) {
+	opts, err := natsOptions(o, logg)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := nats.Connect(endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.GetOrCreateGauge("nats_connected", func() float64 {
+		if conn.IsConnected() {
+			return 1
+		}
+		return 0
+	})
+	logg.Info("connected to nats", "url", conn.ConnectedUrlRedacted())
+
+	return conn, nil
+}
+
+func natsOptions(o NatsOpts, logg *slog.Logger) ([]nats.Option, error) {
+	opts := []nats.Option{
+		nats.Name(natsConnectionName),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			metrics.GetOrCreateCounter(`nats_connection_events_total{event="disconnected"}`).Inc()
+			logg.Warn("disconnected from nats", "error", err)
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			metrics.GetOrCreateCounter(`nats_connection_events_total{event="reconnected"}`).Inc()
+			logg.Info("reconnected to nats", "url", conn.ConnectedUrlRedacted())
+		}),
+		nats.ClosedHandler(func(conn *nats.Conn) {
+			metrics.GetOrCreateCounter(`nats_connection_events_total{event="closed"}`).Inc()
+			logg.Info("nats connection closed", "error", conn.LastError())
+		}),
+		nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+			metrics.GetOrCreateCounter(`nats_connection_events_total{event="error"}`).Inc()
+			logg.Error("nats async error", "error", err)
+		}),
+	}
+
+	var authMethods int
+	for _, set := range []bool{o.CredsFile != "", o.NKeySeedFile != "", o.User != "", o.Token != ""} {
+		if set {
+			authMethods++
+		}
+	}
+	if authMethods > 1 {
+		return nil, errors.New("only one nats auth method of creds file, nkey seed, user and token can be set")
+	}
+
+	switch {
+	case o.CredsFile != "":
+		opts = append(opts, nats.UserCredentials(o.CredsFile))
+	case o.NKeySeedFile != "":
+		nkeyOpt, err := nats.NkeyOptionFromSeed(o.NKeySeedFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load nkey seed: %w", err)
+		}
+		opts = append(opts, nkeyOpt)
+	case o.User != "":
+		opts = append(opts, nats.UserInfo(o.User, o.Password))
+	case o.Token != "":
+		opts = append(opts, nats.Token(o.Token))
+	}
+
+	if (o.TLSCertFile == "") != (o.TLSKeyFile == "") {
+		return nil, errors.New("nats tls cert and key must be set together")
+	}
+	if o.TLSCAFile != "" {
+		opts = append(opts, nats.RootCAs(o.TLSCAFile))
+	}
+	if o.TLSCertFile != "" {
+		opts = append(opts, nats.ClientCert(o.TLSCertFile, o.TLSKeyFile))
+	}
+
+	if o.MaxReconnects != 0 {
+		opts = append(opts, nats.MaxReconnects(o.MaxReconnects))
+	}
+	if o.ReconnectWait > 0 {
+		opts = append(opts, nats.ReconnectWait(o.ReconnectWait))
+	}
+	if o.PingInterval > 0 {
+		opts = append(opts, nats.PingInterval(o.PingInterval))
+	}
+	if o.MaxPingsOutstanding > 0 {
+		opts = append(opts, nats.MaxPingsOutstanding(o.MaxPingsOutstanding))
+	}
+
+	return opts, nil
+}
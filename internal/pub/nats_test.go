@@ -0,0 +1,22 @@
+package pub
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNatsOptions(t *testing.T) {
+	_, err := natsOptions(NatsOpts{User: "tracker", Password: "secret"}, slog.Default())
+	require.NoError(t, err)
+
+	_, err = natsOptions(NatsOpts{User: "tracker", Token: "secret"}, slog.Default())
+	require.Error(t, err)
+
+	_, err = natsOptions(NatsOpts{TLSCertFile: "client.pem"}, slog.Default())
+	require.Error(t, err)
+
+	_, err = natsOptions(NatsOpts{NKeySeedFile: "missing.nk"}, slog.Default())
+	require.Error(t, err)
+}
@@ -6,7 +6,28 @@ import (
 	"github.com/grassrootseconomics/eth-tracker/pkg/event"
 )
 
-type Pub interface {
-	Send(context.Context, event.Event) error
-	Close()
+type (
+	Pub interface {
+		Send(context.Context, event.Event) error
+		Close()
+	}
+
+	// BlockFlusher is implemented by publishers that acknowledge sends asynchronously
+	BlockFlusher interface {
+		// FlushBlock waits until every event of the block sent so far is acknowledged
+		FlushBlock(context.Context, uint64) error
+	}
+)
+
+// sendAndFlush sends a single event synchronously, also when the publisher acknowledges asynchronously
+func sendAndFlush(ctx context.Context, p Pub, payload event.Event) error {
+	if err := p.Send(ctx, payload); err != nil {
+		return err
+	}
+
+	if flusher, ok := p.(BlockFlusher); ok {
+		return flusher.FlushBlock(ctx, payload.Block)
+	}
+
+	return nil
 }
@@ -0,0 +1,395 @@
+package pub
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+)
+
+type (
+	WebhookSubscriber struct {
+		Name   string
+		URL    string
+		Secret string
+		// Contracts and EventTypes limit delivery, all events are delivered when empty
+		Contracts  []string
+		EventTypes []string
+	}
+
+	WebhookOpts struct {
+		Subscribers []WebhookSubscriber
+		// DBPath is the bolt file undelivered events are persisted to
+		DBPath         string
+		Timeout        time.Duration
+		MaxAttempts    int
+		InitialBackoff time.Duration
+		MaxBackoff     time.Duration
+		// RetryInterval is how often persisted events are redelivered
+		RetryInterval time.Duration
+		// QueueSize bounds the in-memory queue per subscriber, events are persisted when it is full
+		QueueSize int
+		Logg      *slog.Logger
+	}
+
+	webhookPub struct {
+		subscribers    []*webhookSubscriber
+		store          *webhookStore
+		client         *http.Client
+		maxAttempts    int
+		initialBackoff time.Duration
+		maxBackoff     time.Duration
+		retryInterval  time.Duration
+		logg           *slog.Logger
+		stopCh         chan struct{}
+		wg             sync.WaitGroup
+	}
+
+	webhookSubscriber struct {
+		WebhookSubscriber
+		eventTypes map[string]struct{}
+		contracts  map[string]struct{}
+		queue      chan webhookDelivery
+	}
+
+	webhookDelivery struct {
+		eventID string
+		body    []byte
+	}
+)
+
+const (
+	WebhookSignatureHeader = "X-Tracker-Signature"
+	WebhookTimestampHeader = "X-Tracker-Timestamp"
+	WebhookEventIDHeader   = "X-Tracker-Event-Id"
+
+	defaultWebhookDBPath         = "db/webhook_db"
+	defaultWebhookTimeout        = 10 * time.Second
+	defaultWebhookMaxAttempts    = 5
+	defaultWebhookInitialBackoff = time.Second
+	defaultWebhookMaxBackoff     = time.Minute
+	defaultWebhookRetryInterval  = time.Minute
+	defaultWebhookQueueSize      = 1024
+)
+
+func NewWebhookPub(o WebhookOpts) (Pub, error) {
+	if o.DBPath == "" {
+		o.DBPath = defaultWebhookDBPath
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = defaultWebhookTimeout
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = defaultWebhookInitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaultWebhookMaxBackoff
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = defaultWebhookRetryInterval
+	}
+	if o.QueueSize <= 0 {
+		o.QueueSize = defaultWebhookQueueSize
+	}
+
+	subscribers := make([]*webhookSubscriber, len(o.Subscribers))
+	names := make(map[string]struct{}, len(o.Subscribers))
+	for i, s := range o.Subscribers {
+		if s.Name == "" || s.URL == "" {
+			return nil, fmt.Errorf("webhook subscriber %d requires a name and url", i)
+		}
+		// Unsigned deliveries cannot be authenticated by the subscriber
+		if s.Secret == "" {
+			return nil, fmt.Errorf("webhook subscriber %s requires a secret", s.Name)
+		}
+		if _, ok := names[s.Name]; ok {
+			return nil, fmt.Errorf("duplicate webhook subscriber %s", s.Name)
+		}
+		names[s.Name] = struct{}{}
+
+		subscribers[i] = &webhookSubscriber{
+			WebhookSubscriber: s,
+			eventTypes:        toSet(s.EventTypes, false),
+			contracts:         toSet(s.Contracts, true),
+			queue:             make(chan webhookDelivery, o.QueueSize),
+		}
+	}
+
+	store, err := newWebhookStore(o.DBPath, o.Subscribers)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &webhookPub{
+		subscribers:    subscribers,
+		store:          store,
+		client:         &http.Client{Timeout: o.Timeout},
+		maxAttempts:    o.MaxAttempts,
+		initialBackoff: o.InitialBackoff,
+		maxBackoff:     o.MaxBackoff,
+		retryInterval:  o.RetryInterval,
+		logg:           o.Logg,
+		stopCh:         make(chan struct{}),
+	}
+
+	for _, s := range subscribers {
+		metrics.GetOrCreateGauge(fmt.Sprintf(`webhook_undelivered{subscriber=%q}`, s.Name), func() float64 {
+			return float64(store.count(s.Name))
+		})
+
+		p.wg.Add(1)
+		go p.deliverQueued(s)
+	}
+
+	p.wg.Add(1)
+	go p.redeliverPersisted()
+
+	return p, nil
+}
+
+// Close persists events that are still queued, they are redelivered on the next start
+func (p *webhookPub) Close() {
+	close(p.stopCh)
+	for _, s := range p.subscribers {
+		close(s.queue)
+	}
+	p.wg.Wait()
+
+	if err := p.store.close(); err != nil {
+		p.logg.Error("could not close webhook store", "error", err)
+	}
+}
+
+// Send queues the event for every matching subscriber, events are persisted when a subscriber's queue is full
+func (p *webhookPub) Send(_ context.Context, payload event.Event) error {
+	var body []byte
+
+	for _, s := range p.subscribers {
+		if !s.match(payload) {
+			continue
+		}
+
+		if body == nil {
+			data, err := payload.Serialize()
+			if err != nil {
+				return err
+			}
+			body = data
+		}
+
+		delivery := webhookDelivery{
+			eventID: payload.ID(),
+			body:    body,
+		}
+
+		select {
+		case s.queue <- delivery:
+		default:
+			if err := p.persist(s, delivery); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *webhookPub) deliverQueued(s *webhookSubscriber) {
+	defer p.wg.Done()
+
+	for delivery := range s.queue {
+		if p.stopping() {
+			if err := p.persist(s, delivery); err != nil {
+				p.logg.Error("could not persist webhook delivery", "subscriber", s.Name, "event", delivery.eventID, "error", err)
+			}
+			continue
+		}
+
+		if err := p.deliverWithRetry(s, delivery); err != nil {
+			p.logg.Warn("webhook delivery failed, persisting for redelivery", "subscriber", s.Name, "event", delivery.eventID, "error", err)
+			if err := p.persist(s, delivery); err != nil {
+				p.logg.Error("could not persist webhook delivery", "subscriber", s.Name, "event", delivery.eventID, "error", err)
+			}
+		}
+	}
+}
+
+func (p *webhookPub) deliverWithRetry(s *webhookSubscriber, delivery webhookDelivery) error {
+	var err error
+
+	for attempt := 1; attempt <= p.maxAttempts; attempt++ {
+		if err = p.deliver(s, delivery); err == nil {
+			return nil
+		}
+		if attempt == p.maxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(p.backoff(attempt))
+		select {
+		case <-p.stopCh:
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+
+	return err
+}
+
+// redeliverPersisted periodically makes a single delivery attempt for persisted events
+func (p *webhookPub) redeliverPersisted() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			for _, s := range p.subscribers {
+				p.redeliver(s)
+			}
+		}
+	}
+}
+
+// redeliver works through the persisted events of the subscriber batch by batch until the store is empty or a delivery fails
+func (p *webhookPub) redeliver(s *webhookSubscriber) {
+	for {
+		var failed bool
+
+		n, err := p.store.forEach(s.Name, func(key []byte, delivery webhookDelivery) bool {
+			if p.stopping() {
+				failed = true
+				return false
+			}
+			if err := p.deliver(s, delivery); err != nil {
+				failed = true
+				return false
+			}
+			if err := p.store.delete(s.Name, key); err != nil {
+				p.logg.Error("could not delete redelivered webhook event", "subscriber", s.Name, "event", delivery.eventID, "error", err)
+				failed = true
+				return false
+			}
+			return true
+		})
+		if err != nil {
+			p.logg.Error("could not read persisted webhook events", "subscriber", s.Name, "error", err)
+			return
+		}
+		if failed || n < webhookRedeliveryBatchSize {
+			return
+		}
+	}
+}
+
+func (p *webhookPub) deliver(s *webhookSubscriber, delivery webhookDelivery) error {
+	metrics.GetOrCreateCounter(fmt.Sprintf(`webhook_delivery_attempts_total{subscriber=%q}`, s.Name)).Inc()
+	startedAt := time.Now()
+
+	timestamp := strconv.FormatInt(startedAt.Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, s.URL, bytes.NewReader(delivery.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(event.ContentTypeHeader, event.ContentTypeJSON)
+	req.Header.Set(WebhookEventIDHeader, delivery.eventID)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(s.Secret, timestamp, delivery.body))
+
+	resp, err := p.client.Do(req)
+	metrics.GetOrCreateHistogram(fmt.Sprintf(`webhook_delivery_duration_seconds{subscriber=%q}`, s.Name)).UpdateDuration(startedAt)
+	if err != nil {
+		metrics.GetOrCreateCounter(fmt.Sprintf(`webhook_deliveries_total{subscriber=%q,status="failed"}`, s.Name)).Inc()
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		metrics.GetOrCreateCounter(fmt.Sprintf(`webhook_deliveries_total{subscriber=%q,status="failed"}`, s.Name)).Inc()
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	metrics.GetOrCreateCounter(fmt.Sprintf(`webhook_deliveries_total{subscriber=%q,status="delivered"}`, s.Name)).Inc()
+	return nil
+}
+
+func (p *webhookPub) persist(s *webhookSubscriber, delivery webhookDelivery) error {
+	metrics.GetOrCreateCounter(fmt.Sprintf(`webhook_persisted_total{subscriber=%q}`, s.Name)).Inc()
+	return p.store.put(s.Name, delivery)
+}
+
+func (p *webhookPub) backoff(attempt int) time.Duration {
+	backoff := p.initialBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > p.maxBackoff {
+		return p.maxBackoff
+	}
+
+	return backoff
+}
+
+func (p *webhookPub) stopping() bool {
+	select {
+	case <-p.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// SignWebhook returns the signature header value, receivers recompute the HMAC-SHA256 of "<timestamp>.<body>" with their secret
+func SignWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *webhookSubscriber) match(ev event.Event) bool {
+	if len(s.eventTypes) > 0 {
+		if _, ok := s.eventTypes[ev.TxType]; !ok {
+			return false
+		}
+	}
+
+	if len(s.contracts) > 0 {
+		if _, ok := s.contracts[strings.ToLower(ev.ContractAddress)]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func toSet(values []string, lowercase bool) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		if lowercase {
+			v = strings.ToLower(v)
+		}
+		set[v] = struct{}{}
+	}
+
+	return set
+}
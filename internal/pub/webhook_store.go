@@ -0,0 +1,144 @@
+package pub
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+type (
+	webhookStore struct {
+		db *bolt.DB
+	}
+
+	persistedDelivery struct {
+		EventID string `json:"eventId"`
+		Body    []byte `json:"body"`
+	}
+)
+
+// Persisted events are redelivered in batches to keep the read transaction short
+const webhookRedeliveryBatchSize = 256
+
+func newWebhookStore(path string, subscribers []WebhookSubscriber) (*webhookStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, s := range subscribers {
+			if _, err := tx.CreateBucketIfNotExists([]byte(s.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &webhookStore{
+		db: db,
+	}, nil
+}
+
+func (s *webhookStore) close() error {
+	return s.db.Close()
+}
+
+// put appends the delivery, redelivered events can arrive after newer events that were delivered on the first attempt
+func (s *webhookStore) put(subscriber string, delivery webhookDelivery) error {
+	value, err := json.Marshal(persistedDelivery{
+		EventID: delivery.eventID,
+		Body:    delivery.body,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(subscriber))
+
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		return b.Put(marshalSequence(seq), value)
+	})
+}
+
+func (s *webhookStore) delete(subscriber string, key []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(subscriber)).Delete(key)
+	})
+}
+
+// forEach visits a batch of the oldest persisted deliveries until fn returns false and returns the size of the batch
+func (s *webhookStore) forEach(subscriber string, fn func([]byte, webhookDelivery) bool) (int, error) {
+	type entry struct {
+		key      []byte
+		delivery webhookDelivery
+	}
+	var entries []entry
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(subscriber)).Cursor()
+
+		for k, v := c.First(); k != nil && len(entries) < webhookRedeliveryBatchSize; k, v = c.Next() {
+			var persisted persistedDelivery
+			if err := json.Unmarshal(v, &persisted); err != nil {
+				return err
+			}
+
+			entries = append(entries, entry{
+				key: append([]byte(nil), k...),
+				delivery: webhookDelivery{
+					eventID: persisted.EventID,
+					body:    persisted.Body,
+				},
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, e := range entries {
+		if !fn(e.key, e.delivery) {
+			break
+		}
+	}
+
+	return len(entries), nil
+}
+
+func (s *webhookStore) count(subscriber string) int {
+	var n int
+
+	s.db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket([]byte(subscriber)); b != nil {
+			n = b.Stats().KeyN
+		}
+		return nil
+	})
+
+	return n
+}
+
+func marshalSequence(seq uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, seq)
+	return b
+}
@@ -0,0 +1,175 @@
+package pub
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "secret"
+
+type testReceiver struct {
+	mu       sync.Mutex
+	received []string
+	failures atomic.Int32
+}
+
+func (r *testReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	if SignWebhook(testWebhookSecret, req.Header.Get(WebhookTimestampHeader), body) != req.Header.Get(WebhookSignatureHeader) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if r.failures.Load() > 0 {
+		r.failures.Add(-1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	r.mu.Lock()
+	r.received = append(r.received, req.Header.Get(WebhookEventIDHeader))
+	r.mu.Unlock()
+}
+
+func (r *testReceiver) events() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.received...)
+}
+
+func newTestWebhookPub(t *testing.T, url string, dbPath string) Pub {
+	p, err := NewWebhookPub(WebhookOpts{
+		Subscribers: []WebhookSubscriber{
+			{
+				Name:       "partner",
+				URL:        url,
+				Secret:     testWebhookSecret,
+				Contracts:  []string{"0x0000000000000000000000000000000000000C01"},
+				EventTypes: []string{"TOKEN_TRANSFER"},
+			},
+		},
+		DBPath:         dbPath,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		RetryInterval:  10 * time.Millisecond,
+		Logg:           slog.Default(),
+	})
+	require.NoError(t, err)
+
+	return p
+}
+
+func testWebhookEvent(txHash string, txType string, contract string) event.Event {
+	return event.Event{
+		TxHash:          txHash,
+		TxType:          txType,
+		ContractAddress: contract,
+		Payload:         &event.TokenTransferPayload{},
+	}
+}
+
+func TestWebhookPub_FilterSignAndRetry(t *testing.T) {
+	receiver := &testReceiver{}
+	receiver.failures.Store(2)
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	p := newTestWebhookPub(t, server.URL, filepath.Join(t.TempDir(), "webhook_db"))
+	defer p.Close()
+
+	ctx := context.Background()
+	require.NoError(t, p.Send(ctx, testWebhookEvent("0x01", "TOKEN_TRANSFER", "0x0000000000000000000000000000000000000c01")))
+	require.NoError(t, p.Send(ctx, testWebhookEvent("0x02", "TOKEN_APPROVE", "0x0000000000000000000000000000000000000c01")))
+	require.NoError(t, p.Send(ctx, testWebhookEvent("0x03", "TOKEN_TRANSFER", "0x0000000000000000000000000000000000000c02")))
+
+	require.Eventually(t, func() bool {
+		return len(receiver.events()) == 1
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, []string{"0x01:0"}, receiver.events())
+}
+
+func TestWebhookPub_PersistAndRedeliver(t *testing.T) {
+	var available atomic.Bool
+	receiver := &testReceiver{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		receiver.ServeHTTP(w, req)
+	}))
+	defer server.Close()
+
+	dbPath := filepath.Join(t.TempDir(), "webhook_db")
+
+	p := newTestWebhookPub(t, server.URL, dbPath)
+	require.NoError(t, p.Send(context.Background(), testWebhookEvent("0x01", "TOKEN_TRANSFER", "0x0000000000000000000000000000000000000c01")))
+
+	store := p.(*webhookPub).store
+	require.Eventually(t, func() bool {
+		return store.count("partner") == 1
+	}, time.Second, 5*time.Millisecond)
+	p.Close()
+
+	// Persisted events survive a restart
+	available.Store(true)
+	p = newTestWebhookPub(t, server.URL, dbPath)
+	defer p.Close()
+
+	require.Eventually(t, func() bool {
+		return len(receiver.events()) == 1
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, 0, p.(*webhookPub).store.count("partner"))
+}
+
+func TestWebhookPub_RedeliversEveryBatch(t *testing.T) {
+	receiver := &testReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	// The ticker never fires, a single redelivery run has to empty the store
+	p, err := NewWebhookPub(WebhookOpts{
+		Subscribers:   []WebhookSubscriber{{Name: "partner", URL: server.URL, Secret: testWebhookSecret}},
+		DBPath:        filepath.Join(t.TempDir(), "webhook_db"),
+		RetryInterval: time.Hour,
+		Logg:          slog.Default(),
+	})
+	require.NoError(t, err)
+	defer p.Close()
+
+	var (
+		webhook    = p.(*webhookPub)
+		subscriber = webhook.subscribers[0]
+		persisted  = 2*webhookRedeliveryBatchSize + 1
+	)
+	for i := range persisted {
+		require.NoError(t, webhook.store.put(subscriber.Name, webhookDelivery{eventID: strconv.Itoa(i), body: []byte("{}")}))
+	}
+
+	webhook.redeliver(subscriber)
+	require.Len(t, receiver.events(), persisted)
+	require.Equal(t, 0, webhook.store.count(subscriber.Name))
+}
+
+func TestNewWebhookPub_RequiresSecret(t *testing.T) {
+	_, err := NewWebhookPub(WebhookOpts{
+		Subscribers: []WebhookSubscriber{{Name: "partner", URL: "http://localhost"}},
+		DBPath:      filepath.Join(t.TempDir(), "webhook_db"),
+	})
+	require.ErrorContains(t, err, "requires a secret")
+}
@@ -0,0 +1,81 @@
+package revert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3"
+)
+
+type (
+	DecoderOpts struct {
+		CustomErrors []string
+	}
+
+	Decoder struct {
+		customErrors map[[4]byte]*w3.Func
+	}
+)
+
+var (
+	errorSelector = w3.MustNewFunc("Error(string)", "").Selector
+	panicSelector = w3.MustNewFunc("Panic(uint256)", "").Selector
+)
+
+func NewDecoder(o DecoderOpts) (*Decoder, error) {
+	customErrors := make(map[[4]byte]*w3.Func, len(o.CustomErrors))
+
+	for _, signature := range o.CustomErrors {
+		if signature == "" {
+			continue
+		}
+
+		customError, err := w3.NewFunc(signature, "")
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom error signature %s: %v", signature, err)
+		}
+		customErrors[customError.Selector] = customError
+	}
+
+	return &Decoder{
+		customErrors: customErrors,
+	}, nil
+}
+
+func (d *Decoder) Decode(revertData []byte) string {
+	if len(revertData) < 4 {
+		return ""
+	}
+
+	switch selector := [4]byte(revertData[:4]); selector {
+	case errorSelector, panicSelector:
+		reason, err := abi.UnpackRevert(revertData)
+		if err != nil {
+			return hexutil.Encode(revertData)
+		}
+
+		if selector == panicSelector {
+			return fmt.Sprintf("panic: %s", reason)
+		}
+		return reason
+	default:
+		customError, ok := d.customErrors[selector]
+		if !ok {
+			return hexutil.Encode(revertData)
+		}
+
+		args, err := customError.Args.Unpack(revertData[4:])
+		if err != nil {
+			return hexutil.Encode(revertData)
+		}
+
+		formattedArgs := make([]string, len(args))
+		for i, arg := range args {
+			formattedArgs[i] = fmt.Sprint(arg)
+		}
+
+		return fmt.Sprintf("%s(%s)", customError.Signature[:strings.Index(customError.Signature, "(")], strings.Join(formattedArgs, ", "))
+	}
+}
@@ -0,0 +1,48 @@
+package revert
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	decoder, err := NewDecoder(DecoderOpts{
+		CustomErrors: []string{"InsufficientBalance(uint256,uint256)"},
+	})
+	require.NoError(t, err)
+
+	encode := func(signature string, args ...any) []byte {
+		data, err := w3.MustNewFunc(signature, "").EncodeArgs(args...)
+		require.NoError(t, err)
+		return data
+	}
+
+	tests := []struct {
+		name       string
+		revertData []byte
+		want       string
+	}{
+		{"Empty", nil, ""},
+		{"ShortData", []byte{0x08, 0xc3}, ""},
+		{"Error", encode("Error(string)", "insufficient allowance"), "insufficient allowance"},
+		{"Panic", encode("Panic(uint256)", big.NewInt(0x11)), "panic: arithmetic underflow or overflow"},
+		{"UnknownPanicCode", encode("Panic(uint256)", big.NewInt(0x99)), "panic: unknown panic code: 0x99"},
+		{"CustomError", encode("InsufficientBalance(uint256,uint256)", big.NewInt(10), big.NewInt(20)), "InsufficientBalance(10, 20)"},
+		{"UnknownCustomError", encode("Unauthorized()"), "0x82b42900"},
+		{"MalformedError", []byte{0x08, 0xc3, 0x79, 0xa0, 0x01}, "0x08c379a001"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, decoder.Decode(tc.revertData))
+		})
+	}
+}
+
+func TestNewDecoder_RejectsInvalidSignature(t *testing.T) {
+	_, err := NewDecoder(DecoderOpts{CustomErrors: []string{"InsufficientBalance(uint256"}})
+	require.Error(t, err)
+}
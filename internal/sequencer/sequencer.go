@@ -0,0 +1,361 @@
+// Package sequencer releases the events of concurrently processed blocks to the publisher in block order
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/VictoriaMetrics/metrics"
+	"github.com/grassrootseconomics/eth-tracker/internal/pub"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+)
+
+type (
+	SequencerOpts struct {
+		Pub pub.Pub
+		// MaxBlocks bounds the reorder buffer, further blocks are only queued once the lowest block is published
+		MaxBlocks int
+		// RetryInterval is the wait after a failed publish, the head of the buffer is retried before any higher block
+		RetryInterval time.Duration
+		Logg          *slog.Logger
+	}
+
+	// Sequencer publishes the events of concurrently processed blocks in block order. A block holds a slot from the
+	// moment it is queued until its events are published, a failed block keeps its slot and holds back every
+	// higher block until its retry completes or it is dead lettered
+	Sequencer struct {
+		pub           pub.Pub
+		retryInterval time.Duration
+		logg          *slog.Logger
+		room          chan struct{}
+		notifyCh      chan struct{}
+		ctx           context.Context
+		cancel        context.CancelFunc
+		wg            sync.WaitGroup
+
+		mu           sync.Mutex
+		slots        map[uint64]*slot
+		lastReleased uint64
+
+		waitDuration *metrics.Histogram
+	}
+
+	slot struct {
+		events []event.Event
+		// commit marks the block as processed once its events are published
+		commit  func() error
+		begun   bool
+		ready   bool
+		skipped bool
+		// late blocks are lower than an already published block, they are published as soon as they are ready
+		late    bool
+		readyAt time.Time
+	}
+
+	// Batch collects the events of a block in the order they are routed, which is receipt and log index order
+	Batch struct {
+		mu     sync.Mutex
+		events []event.Event
+	}
+
+	batchKey struct{}
+)
+
+const (
+	defaultMaxBlocks     = 256
+	defaultRetryInterval = 5 * time.Second
+)
+
+var ErrNoBatch = errors.New("sequencer batch missing from context")
+
+func New(o SequencerOpts) *Sequencer {
+	if o.MaxBlocks <= 0 {
+		o.MaxBlocks = defaultMaxBlocks
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = defaultRetryInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &Sequencer{
+		pub:           o.Pub,
+		retryInterval: o.RetryInterval,
+		logg:          o.Logg,
+		room:          make(chan struct{}, o.MaxBlocks),
+		notifyCh:      make(chan struct{}, 1),
+		ctx:           ctx,
+		cancel:        cancel,
+		slots:         make(map[uint64]*slot),
+		waitDuration:  metrics.GetOrCreateHistogram("sequencer_head_of_line_wait_seconds"),
+	}
+
+	metrics.GetOrCreateGauge("sequencer_buffered_blocks", func() float64 {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		return float64(len(s.slots))
+	})
+	metrics.GetOrCreateGauge("sequencer_head_of_line_blocked", func() float64 {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var blocked int
+		for _, sl := range s.slots {
+			if sl.ready {
+				blocked++
+			}
+		}
+		return float64(blocked)
+	})
+
+	return s
+}
+
+// Start publishes released blocks in the background until Stop is called
+func (s *Sequencer) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop publishes what is ready without retrying, unpublished blocks are not marked as processed and are backfilled after a restart
+func (s *Sequencer) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Sequencer) run() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.drain(context.Background())
+			s.logg.Debug("sequencer shutting down")
+			return
+		case <-s.notifyCh:
+		}
+
+		if err := s.drain(s.ctx); err != nil && s.ctx.Err() == nil {
+			metrics.GetOrCreateCounter("sequencer_publish_errors_total").Inc()
+			s.logg.Error("sequencer publish error, retrying", "retry_in", s.retryInterval, "error", err)
+
+			select {
+			case <-s.ctx.Done():
+			case <-time.After(s.retryInterval):
+				s.notify()
+			}
+		}
+	}
+}
+
+func (s *Sequencer) notify() {
+	select {
+	case s.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Reserve takes a slot for the block when it is queued, it waits while the reorder buffer is full. Blocks are released
+// in block order regardless of which finishes processing first
+func (s *Sequencer) Reserve(ctx context.Context, block uint64) error {
+	if s.registered(block) {
+		return nil
+	}
+
+	select {
+	case s.room <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.slots[block]; ok {
+		<-s.room
+		return nil
+	}
+	s.slots[block] = &slot{
+		late: s.lastReleased > 0 && block <= s.lastReleased,
+	}
+	if s.slots[block].late {
+		// A backfilled or requeued block cannot be ordered before blocks that were already published
+		metrics.GetOrCreateCounter("sequencer_out_of_order_total").Inc()
+	}
+
+	return nil
+}
+
+// Begin starts processing a block, blocks that were not reserved take a slot first
+func (s *Sequencer) Begin(ctx context.Context, block uint64) error {
+	if err := s.Reserve(ctx, block); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sl, ok := s.slots[block]
+	if !ok || sl.begun || sl.ready {
+		return fmt.Errorf("block %d is already being sequenced", block)
+	}
+	sl.begun = true
+
+	return nil
+}
+
+func (s *Sequencer) registered(block uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.slots[block]
+	return ok
+}
+
+// Release hands over the events of a processed block without waiting for them to be published, commit is called once they are
+func (s *Sequencer) Release(block uint64, events []event.Event, commit func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sl, ok := s.slots[block]
+	if !ok || !sl.begun {
+		return fmt.Errorf("block %d was not registered with the sequencer", block)
+	}
+	sl.events = events
+	sl.commit = commit
+	sl.ready = true
+	sl.readyAt = time.Now()
+	s.notify()
+
+	return nil
+}
+
+// Fail keeps the slot of a block that failed to process, higher blocks are held back until its retry is released
+func (s *Sequencer) Fail(block uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sl, ok := s.slots[block]; ok && !sl.ready {
+		sl.begun = false
+	}
+}
+
+// Skip gives up on a dead lettered block so that higher blocks are published, a requeue is published as a late block
+func (s *Sequencer) Skip(block uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sl, ok := s.slots[block]; ok && !sl.ready {
+		sl.skipped = true
+		sl.ready = true
+		sl.readyAt = time.Now()
+		s.notify()
+	}
+}
+
+// drain publishes ready blocks from the lowest slot upwards until it reaches a block still processing,
+// a block that fails to publish stays at the head of the buffer
+func (s *Sequencer) drain(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		block, next := s.next()
+		s.mu.Unlock()
+		if next == nil {
+			return nil
+		}
+
+		if !next.skipped {
+			if err := s.publish(ctx, block, next.events); err != nil {
+				return fmt.Errorf("block %d: %w", block, err)
+			}
+			s.waitDuration.UpdateDuration(next.readyAt)
+
+			if next.commit != nil {
+				if err := next.commit(); err != nil {
+					s.logg.Error("could not mark sequenced block as processed", "block", block, "error", err)
+				}
+			}
+		}
+
+		s.mu.Lock()
+		delete(s.slots, block)
+		if !next.late && block > s.lastReleased {
+			s.lastReleased = block
+		}
+		s.mu.Unlock()
+		<-s.room
+	}
+}
+
+// next is a ready late block or the lowest slot if it is ready, the buffer is small enough for a linear scan
+func (s *Sequencer) next() (uint64, *slot) {
+	var (
+		headBlock uint64
+		headSlot  *slot
+	)
+
+	for block, sl := range s.slots {
+		if sl.late {
+			if sl.ready {
+				return block, sl
+			}
+			continue
+		}
+		if headSlot == nil || block < headBlock {
+			headBlock = block
+			headSlot = sl
+		}
+	}
+
+	if headSlot == nil || !headSlot.ready {
+		return 0, nil
+	}
+
+	return headBlock, headSlot
+}
+
+func (s *Sequencer) publish(ctx context.Context, block uint64, events []event.Event) error {
+	for _, ev := range events {
+		if err := s.pub.Send(ctx, ev); err != nil {
+			return err
+		}
+	}
+
+	if flusher, ok := s.pub.(pub.BlockFlusher); ok {
+		return flusher.FlushBlock(ctx, block)
+	}
+
+	return nil
+}
+
+// WithBatch returns a context whose collected events are added to the returned batch
+func WithBatch(ctx context.Context) (context.Context, *Batch) {
+	batch := &Batch{}
+	return context.WithValue(ctx, batchKey{}, batch), batch
+}
+
+// Collect is a router callback that adds the event to the batch of the context
+func Collect(ctx context.Context, ev event.Event) error {
+	batch, ok := ctx.Value(batchKey{}).(*Batch)
+	if !ok {
+		return ErrNoBatch
+	}
+
+	batch.mu.Lock()
+	batch.events = append(batch.events, ev)
+	batch.mu.Unlock()
+
+	return nil
+}
+
+func (b *Batch) Events() []event.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.events
+}
@@ -0,0 +1,212 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+type testPub struct {
+	mu       sync.Mutex
+	sent     []string
+	failures int
+}
+
+func (p *testPub) Send(_ context.Context, ev event.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("unavailable")
+	}
+	p.sent = append(p.sent, ev.TxHash)
+	return nil
+}
+
+func (p *testPub) Close() {}
+
+func (p *testPub) events() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]string(nil), p.sent...)
+}
+
+func newTestSequencer(t *testing.T, publisher *testPub, maxBlocks int) *Sequencer {
+	s := New(SequencerOpts{Pub: publisher, MaxBlocks: maxBlocks, RetryInterval: 10 * time.Millisecond, Logg: slog.Default()})
+	s.Start()
+	t.Cleanup(s.Stop)
+
+	return s
+}
+
+// release begins and releases the block as a worker would, the returned function reports whether it was committed
+func release(t *testing.T, s *Sequencer, block uint64, events ...string) func() bool {
+	require.NoError(t, s.Begin(context.Background(), block))
+
+	var (
+		mu        sync.Mutex
+		committed bool
+	)
+	batch := make([]event.Event, len(events))
+	for i, txHash := range events {
+		batch[i] = event.Event{TxHash: txHash}
+	}
+	require.NoError(t, s.Release(block, batch, func() error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		committed = true
+		return nil
+	}))
+
+	return func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return committed
+	}
+}
+
+func requirePublished(t *testing.T, publisher *testPub, want ...string) {
+	require.Eventually(t, func() bool {
+		return len(publisher.events()) >= len(want)
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, want, publisher.events())
+}
+
+func TestSequencer_ReleasesInBlockOrder(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		publisher = &testPub{}
+		s         = newTestSequencer(t, publisher, 4)
+	)
+
+	for _, block := range []uint64{10, 11, 12} {
+		require.NoError(t, s.Reserve(ctx, block))
+	}
+
+	// Block 12 is held back until 10 and 11 are published
+	committed := release(t, s, 12, "12-0", "12-1")
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, publisher.events())
+	require.False(t, committed())
+
+	release(t, s, 11, "11-0")
+	release(t, s, 10, "10-0")
+
+	requirePublished(t, publisher, "10-0", "11-0", "12-0", "12-1")
+	require.Eventually(t, committed, time.Second, 5*time.Millisecond)
+}
+
+func TestSequencer_BoundedBuffer(t *testing.T) {
+	s := newTestSequencer(t, &testPub{}, 1)
+
+	require.NoError(t, s.Reserve(context.Background(), 10))
+	require.NoError(t, s.Begin(context.Background(), 10))
+	require.Error(t, s.Begin(context.Background(), 10))
+
+	// Reserved slots count against the buffer
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, s.Reserve(ctx, 11), context.DeadlineExceeded)
+
+	require.NoError(t, s.Release(10, nil, nil))
+	require.NoError(t, s.Reserve(context.Background(), 11))
+}
+
+func TestSequencer_ReservedBlocksKeepPushOrder(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		publisher = &testPub{}
+		s         = newTestSequencer(t, publisher, 4)
+	)
+
+	// Both blocks are queued before either starts processing
+	require.NoError(t, s.Reserve(ctx, 10))
+	require.NoError(t, s.Reserve(ctx, 11))
+
+	// Block 11 starts and completes first
+	release(t, s, 11, "11-0")
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, publisher.events())
+
+	release(t, s, 10, "10-0")
+	requirePublished(t, publisher, "10-0", "11-0")
+}
+
+func TestSequencer_FailedBlockHoldsHigherBlocks(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		publisher = &testPub{}
+		s         = newTestSequencer(t, publisher, 4)
+	)
+
+	require.NoError(t, s.Reserve(ctx, 20))
+	require.NoError(t, s.Reserve(ctx, 21))
+
+	require.NoError(t, s.Begin(ctx, 20))
+	s.Fail(20)
+	release(t, s, 21, "21-0")
+
+	// Block 21 waits for the retry of block 20
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, publisher.events())
+
+	release(t, s, 20, "20-0")
+	requirePublished(t, publisher, "20-0", "21-0")
+}
+
+func TestSequencer_RetriesFailedPublishAtHead(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		publisher = &testPub{failures: 2}
+		s         = newTestSequencer(t, publisher, 4)
+	)
+
+	require.NoError(t, s.Reserve(ctx, 30))
+	require.NoError(t, s.Reserve(ctx, 31))
+
+	committed := release(t, s, 30, "30-0")
+	release(t, s, 31, "31-0")
+
+	requirePublished(t, publisher, "30-0", "31-0")
+	require.True(t, committed())
+}
+
+func TestSequencer_SkipsDeadLetteredBlock(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		publisher = &testPub{}
+		s         = newTestSequencer(t, publisher, 4)
+	)
+
+	require.NoError(t, s.Reserve(ctx, 40))
+	require.NoError(t, s.Reserve(ctx, 41))
+
+	require.NoError(t, s.Begin(ctx, 40))
+	s.Fail(40)
+	release(t, s, 41, "41-0")
+	s.Skip(40)
+	requirePublished(t, publisher, "41-0")
+
+	// A requeued dead letter is published as soon as it is processed
+	require.NoError(t, s.Reserve(ctx, 40))
+	release(t, s, 40, "40-0")
+	requirePublished(t, publisher, "41-0", "40-0")
+}
+
+func TestCollect_RequiresBatch(t *testing.T) {
+	require.ErrorIs(t, Collect(context.Background(), event.Event{}), ErrNoBatch)
+
+	ctx, batch := WithBatch(context.Background())
+	require.NoError(t, Collect(ctx, event.Event{TxHash: "0x01"}))
+	require.Len(t, batch.Events(), 1)
+}
@@ -55,9 +55,16 @@ func (s *Stats) APIStatsResponse(ctx context.Context) (map[string]interface{}, e
 		return nil, err
 	}
 
+	laneQueueSize := make(map[string]uint64)
+	for _, lane := range pool.Lanes() {
+		laneQueueSize[lane.String()] = s.pool.LaneSize(lane)
+	}
+
 	return map[string]interface{}{
 		"latestBlock":       s.GetLatestBlock(),
 		"poolQueueSize":     s.pool.Size(),
+		"poolLaneQueueSize": laneQueueSize,
+		"poolInFlight":      s.pool.InFlight(),
 		"poolActiveWorkers": s.pool.ActiveWorkers(),
 		"cacheSize":         cacheSize,
 	}, nil
@@ -80,6 +87,9 @@ func (s *Stats) StartStatsPrinter() {
 			s.logg.Info("block stats",
 				"latest_block", s.GetLatestBlock(),
 				"pool_queue_size", s.pool.Size(),
+				"pool_realtime_queue_size", s.pool.LaneSize(pool.LaneRealtime),
+				"pool_reprocess_queue_size", s.pool.LaneSize(pool.LaneReprocess),
+				"pool_backfill_queue_size", s.pool.LaneSize(pool.LaneBackfill),
 				"pool_active_workers", s.pool.ActiveWorkers(),
 				"cache_size", cacheSize,
 			)
@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/grassrootseconomics/eth-tracker/internal/pool"
 )
 
 type BlockQueueFn func(uint64) error
@@ -62,7 +63,7 @@ func (s *Syncer) receiveRealtimeBlocks(ctx context.Context, fn BlockQueueFn) (et
 }
 
 func (s *Syncer) queueRealtimeBlock(blockNumber uint64) error {
-	s.pool.Push(blockNumber)
+	s.pool.Push(blockNumber, pool.LaneRealtime)
 	s.stats.SetLatestBlock(blockNumber)
 	if err := s.db.SetUpperBound(blockNumber); err != nil {
 		return err
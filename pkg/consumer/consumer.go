@@ -0,0 +1,297 @@
+// Package consumer provides at-least-once delivery of tracker events from the TRACKER JetStream stream
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/subject"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+type (
+	// Handler is called at least once for every matching event, returning an error schedules a redelivery
+	Handler func(context.Context, event.Event) error
+
+	Consumer interface {
+		// Run blocks and dispatches events to the handler until the context is cancelled
+		Run(context.Context, Handler) error
+	}
+
+	Filter struct {
+		// EventTypes limits delivery to the TxTypes, all types are delivered when empty
+		EventTypes []string
+		// Contracts limits delivery to events emitted by the contracts, all contracts are delivered when empty
+		Contracts []string
+	}
+
+	JetStreamOpts struct {
+		JetStream jetstream.JetStream
+		// Stream defaults to TRACKER
+		Stream string
+		// SubjectTemplate must match the tracker's jetstream.subject_template, defaults to {stream}.{eventType}
+		SubjectTemplate string
+		Durable         string
+		Filter          Filter
+		// Backoff is the redelivery delay for each failed attempt, the last value is reused for further attempts
+		Backoff []time.Duration
+		// MaxDeliver is the number of attempts after which a failing event is terminated, unlimited when 0
+		MaxDeliver int
+		AckWait    time.Duration
+		Logg       *slog.Logger
+	}
+
+	jetStreamConsumer struct {
+		consumer   jetstream.Consumer
+		filter     *filter
+		backoff    []time.Duration
+		maxDeliver int
+		logg       *slog.Logger
+	}
+
+	filter struct {
+		eventTypes map[string]struct{}
+		contracts  map[string]struct{}
+	}
+
+	cloudEvent struct {
+		DataContentType string          `json:"datacontenttype"`
+		Data            json.RawMessage `json:"data"`
+		DataBase64      []byte          `json:"data_base64"`
+	}
+)
+
+const (
+	defaultStream  = "TRACKER"
+	defaultAckWait = 30 * time.Second
+
+	cloudEventsContentType = "application/cloudevents+json"
+)
+
+var defaultBackoff = []time.Duration{
+	time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	time.Minute,
+}
+
+func NewJetStreamConsumer(ctx context.Context, o JetStreamOpts) (Consumer, error) {
+	if o.Durable == "" {
+		return nil, errors.New("durable consumer name required")
+	}
+	if o.Stream == "" {
+		o.Stream = defaultStream
+	}
+	if o.AckWait == 0 {
+		o.AckWait = defaultAckWait
+	}
+	if len(o.Backoff) == 0 {
+		o.Backoff = defaultBackoff
+	}
+	if o.Logg == nil {
+		o.Logg = slog.Default()
+	}
+
+	subjects, err := subject.Parse(o.SubjectTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	maxDeliver := o.MaxDeliver
+	if maxDeliver == 0 {
+		maxDeliver = -1
+	}
+
+	consumer, err := o.JetStream.CreateOrUpdateConsumer(ctx, o.Stream, jetstream.ConsumerConfig{
+		Durable:        o.Durable,
+		FilterSubjects: filterSubjects(subjects, o.Stream, o.Filter),
+		AckPolicy:      jetstream.AckExplicitPolicy,
+		AckWait:        o.AckWait,
+		MaxDeliver:     maxDeliver,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &jetStreamConsumer{
+		consumer:   consumer,
+		filter:     newFilter(o.Filter),
+		backoff:    o.Backoff,
+		maxDeliver: o.MaxDeliver,
+		logg:       o.Logg,
+	}, nil
+}
+
+func (c *jetStreamConsumer) Run(ctx context.Context, handler Handler) error {
+	iter, err := c.consumer.Messages()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		iter.Stop()
+	}()
+
+	for {
+		msg, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, jetstream.ErrMsgIteratorClosed) {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		c.process(ctx, handler, msg)
+	}
+}
+
+func (c *jetStreamConsumer) process(ctx context.Context, handler Handler, msg jetstream.Msg) {
+	ev, err := Decode(msg.Data(), msg.Headers().Get(event.ContentTypeHeader))
+	if err != nil {
+		// Redelivering an undecodable message can never succeed
+		c.logg.Error("could not decode event", "subject", msg.Subject(), "error", err)
+		if err := msg.Term(); err != nil {
+			c.logg.Error("could not terminate message", "subject", msg.Subject(), "error", err)
+		}
+		return
+	}
+
+	if !c.filter.match(ev) {
+		if err := msg.Ack(); err != nil {
+			c.logg.Error("could not ack message", "event", ev.ID(), "error", err)
+		}
+		return
+	}
+
+	if err := handler(ctx, ev); err != nil {
+		var attempt uint64 = 1
+		if metadata, err := msg.Metadata(); err == nil {
+			attempt = metadata.NumDelivered
+		}
+
+		if c.maxDeliver > 0 && attempt >= uint64(c.maxDeliver) {
+			c.logg.Error("event handler failed, giving up", "event", ev.ID(), "attempt", attempt, "error", err)
+			if err := msg.Term(); err != nil {
+				c.logg.Error("could not terminate message", "event", ev.ID(), "error", err)
+			}
+			return
+		}
+
+		c.logg.Warn("event handler failed, scheduling redelivery", "event", ev.ID(), "attempt", attempt, "error", err)
+		if err := msg.NakWithDelay(backoffDelay(c.backoff, attempt)); err != nil {
+			c.logg.Error("could not nak message", "event", ev.ID(), "error", err)
+		}
+		return
+	}
+
+	if err := msg.Ack(); err != nil {
+		c.logg.Error("could not ack message", "event", ev.ID(), "error", err)
+	}
+}
+
+// Decode decodes a published message in any of the supported formats, including CloudEvents structured mode
+func Decode(data []byte, contentType string) (event.Event, error) {
+	if contentType != cloudEventsContentType {
+		return event.DeserializeAs(data, contentType)
+	}
+
+	var ce cloudEvent
+	if err := json.Unmarshal(data, &ce); err != nil {
+		return event.Event{}, err
+	}
+
+	if len(ce.DataBase64) > 0 {
+		return event.DeserializeAs(ce.DataBase64, ce.DataContentType)
+	}
+
+	return event.DeserializeAs(ce.Data, ce.DataContentType)
+}
+
+// filterSubjects narrows the consumer server side with the filters the subject template carries
+func filterSubjects(t subject.Template, stream string, f Filter) []string {
+	eventTypes := f.EventTypes
+	if len(eventTypes) == 0 || !t.Has(subject.EventType) {
+		eventTypes = []string{""}
+	}
+
+	contracts := f.Contracts
+	if len(contracts) == 0 || !t.Has(subject.Contract) {
+		contracts = []string{""}
+	}
+
+	if len(eventTypes) == 1 && eventTypes[0] == "" && len(contracts) == 1 && contracts[0] == "" {
+		return nil
+	}
+
+	var (
+		filterSubjects []string
+		seen           = make(map[string]struct{})
+	)
+	for _, eventType := range eventTypes {
+		for _, contract := range contracts {
+			filterSubject := t.Format(subject.Values{
+				Stream:    stream,
+				EventType: eventType,
+				Contract:  contract,
+			})
+			if _, ok := seen[filterSubject]; ok {
+				continue
+			}
+			seen[filterSubject] = struct{}{}
+			filterSubjects = append(filterSubjects, filterSubject)
+		}
+	}
+
+	return filterSubjects
+}
+
+func backoffDelay(backoff []time.Duration, attempt uint64) time.Duration {
+	if attempt == 0 {
+		attempt = 1
+	}
+	if attempt > uint64(len(backoff)) {
+		return backoff[len(backoff)-1]
+	}
+
+	return backoff[attempt-1]
+}
+
+func newFilter(f Filter) *filter {
+	eventTypes := make(map[string]struct{}, len(f.EventTypes))
+	for _, eventType := range f.EventTypes {
+		eventTypes[eventType] = struct{}{}
+	}
+
+	contracts := make(map[string]struct{}, len(f.Contracts))
+	for _, contract := range f.Contracts {
+		contracts[strings.ToLower(contract)] = struct{}{}
+	}
+
+	return &filter{
+		eventTypes: eventTypes,
+		contracts:  contracts,
+	}
+}
+
+// match is also applied to event types so that the fake behaves like the subject filtered JetStream consumer
+func (f *filter) match(ev event.Event) bool {
+	if len(f.eventTypes) > 0 {
+		if _, ok := f.eventTypes[ev.TxType]; !ok {
+			return false
+		}
+	}
+
+	if len(f.contracts) > 0 {
+		if _, ok := f.contracts[strings.ToLower(ev.ContractAddress)]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
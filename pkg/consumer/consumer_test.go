@@ -0,0 +1,79 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/grassrootseconomics/eth-tracker/pkg/subject"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake_FilterAndRedelivery(t *testing.T) {
+	fake := NewFake(FakeOpts{
+		Filter: Filter{
+			EventTypes: []string{"TOKEN_TRANSFER"},
+			Contracts:  []string{"0x0000000000000000000000000000000000000C01"},
+		},
+		MaxDeliver: 3,
+	})
+
+	require.NoError(t, fake.Push(
+		event.Event{TxHash: "0x01", TxType: "TOKEN_TRANSFER", ContractAddress: "0x0000000000000000000000000000000000000c01", Payload: &event.TokenTransferPayload{Value: "1"}},
+		event.Event{TxHash: "0x02", TxType: "TOKEN_APPROVE", ContractAddress: "0x0000000000000000000000000000000000000c01", Payload: &event.TokenApprovePayload{}},
+		event.Event{TxHash: "0x03", TxType: "TOKEN_TRANSFER", ContractAddress: "0x0000000000000000000000000000000000000c02", Payload: &event.TokenTransferPayload{}},
+		event.Event{TxHash: "0x04", TxType: "TOKEN_TRANSFER", ContractAddress: "0x0000000000000000000000000000000000000c01", Payload: &event.TokenTransferPayload{Value: "4"}},
+	))
+
+	attempts := map[string]int{}
+	err := fake.Run(context.Background(), func(_ context.Context, ev event.Event) error {
+		attempts[ev.TxHash]++
+		require.IsType(t, &event.TokenTransferPayload{}, ev.Payload)
+
+		// 0x01 succeeds on the second attempt, 0x04 never does
+		if (ev.TxHash == "0x01" && attempts[ev.TxHash] < 2) || ev.TxHash == "0x04" {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]int{"0x01": 2, "0x04": 3}, attempts)
+	require.Len(t, fake.Acked(), 1)
+	require.Equal(t, "0x01", fake.Acked()[0].TxHash)
+	require.Len(t, fake.Terminated(), 1)
+	require.Equal(t, "0x04", fake.Terminated()[0].TxHash)
+	require.Equal(t, 3, fake.Redeliveries())
+}
+
+func TestBackoffDelay(t *testing.T) {
+	backoff := []time.Duration{time.Second, 5 * time.Second}
+
+	require.Equal(t, time.Second, backoffDelay(backoff, 1))
+	require.Equal(t, 5*time.Second, backoffDelay(backoff, 2))
+	require.Equal(t, 5*time.Second, backoffDelay(backoff, 10))
+}
+
+func TestFilterSubjects(t *testing.T) {
+	defaultTemplate, err := subject.Parse("")
+	require.NoError(t, err)
+
+	require.Nil(t, filterSubjects(defaultTemplate, "TRACKER", Filter{}))
+	require.Equal(t, []string{"TRACKER.TOKEN_TRANSFER"}, filterSubjects(defaultTemplate, "TRACKER", Filter{
+		EventTypes: []string{"TOKEN_TRANSFER"},
+		Contracts:  []string{"0xAbC"},
+	}))
+
+	contractTemplate, err := subject.Parse("{stream}.{chainId}.{eventType}.{contract}")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"TRACKER.*.*.0xabc"}, filterSubjects(contractTemplate, "TRACKER", Filter{
+		Contracts: []string{"0xAbC", "0xabc"},
+	}))
+	require.Equal(t, []string{"TRACKER.*.TOKEN_MINT.0xabc", "TRACKER.*.TOKEN_BURN.0xabc"}, filterSubjects(contractTemplate, "TRACKER", Filter{
+		EventTypes: []string{"TOKEN_MINT", "TOKEN_BURN"},
+		Contracts:  []string{"0xabc"},
+	}))
+}
@@ -0,0 +1,137 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+)
+
+type (
+	FakeOpts struct {
+		Filter     Filter
+		MaxDeliver int
+	}
+
+	// Fake is an in-memory Consumer for unit tests, redeliveries happen immediately instead of after a backoff
+	Fake struct {
+		mu           sync.Mutex
+		filter       *filter
+		maxDeliver   int
+		queue        []fakeMsg
+		acked        []event.Event
+		terminated   []event.Event
+		redeliveries int
+	}
+
+	fakeMsg struct {
+		event    event.Event
+		attempts int
+	}
+)
+
+func NewFake(o FakeOpts) *Fake {
+	return &Fake{
+		filter:     newFilter(o.Filter),
+		maxDeliver: o.MaxDeliver,
+	}
+}
+
+// Push queues the events, they are round tripped through the JSON encoding so handlers see typed payloads as in production
+func (f *Fake) Push(events ...event.Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, ev := range events {
+		data, err := ev.Serialize()
+		if err != nil {
+			return err
+		}
+
+		decoded, err := event.Deserialize(data)
+		if err != nil {
+			return err
+		}
+
+		f.queue = append(f.queue, fakeMsg{event: decoded})
+	}
+
+	return nil
+}
+
+// Run dispatches queued events until the queue is drained or the context is cancelled
+func (f *Fake) Run(ctx context.Context, handler Handler) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		msg, ok := f.next()
+		if !ok {
+			return nil
+		}
+
+		if !f.filter.match(msg.event) {
+			continue
+		}
+
+		msg.attempts++
+		if err := handler(ctx, msg.event); err != nil {
+			f.failed(msg)
+			continue
+		}
+
+		f.mu.Lock()
+		f.acked = append(f.acked, msg.event)
+		f.mu.Unlock()
+	}
+}
+
+func (f *Fake) next() (fakeMsg, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.queue) == 0 {
+		return fakeMsg{}, false
+	}
+
+	msg := f.queue[0]
+	f.queue = f.queue[1:]
+
+	return msg, true
+}
+
+func (f *Fake) failed(msg fakeMsg) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.maxDeliver > 0 && msg.attempts >= f.maxDeliver {
+		f.terminated = append(f.terminated, msg.event)
+		return
+	}
+
+	f.redeliveries++
+	f.queue = append(f.queue, msg)
+}
+
+func (f *Fake) Acked() []event.Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]event.Event(nil), f.acked...)
+}
+
+// Terminated returns the events that exhausted MaxDeliver
+func (f *Fake) Terminated() []event.Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]event.Event(nil), f.terminated...)
+}
+
+func (f *Fake) Redeliveries() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.redeliveries
+}
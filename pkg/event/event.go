@@ -1,21 +1,82 @@
 package event
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 type (
 	Event struct {
-		Block           uint64         `json:"block"`
-		ContractAddress string         `json:"contractAddress"`
-		Success         bool           `json:"success"`
-		Timestamp       uint64         `json:"timestamp"`
-		TxHash          string         `json:"transactionHash"`
-		TxType          string         `json:"transactionType"`
-		Payload         map[string]any `json:"payload"`
-		Index           uint           `json:"-"`
+		SchemaVersion   uint   `json:"schemaVersion"`
+		EventID         string `json:"eventId"`
+		Block           uint64 `json:"block"`
+		BlockHash       string `json:"blockHash"`
+		ContractAddress string `json:"contractAddress"`
+		Success         bool   `json:"success"`
+		Timestamp       uint64 `json:"timestamp"`
+		TxHash          string `json:"transactionHash"`
+		TxIndex         uint   `json:"transactionIndex"`
+		TxType          string `json:"transactionType"`
+		Payload         any    `json:"payload"`
+		Tx              *Tx    `json:"tx,omitempty"`
+		// Index is the log index, it is 0 for events derived from input data
+		Index uint `json:"logIndex"`
+		// CallIndex is only set for events derived from input data, the position of the call within the transaction
+		CallIndex *uint `json:"callIndex,omitempty"`
+		// UserOpSender is the smart account for events derived from logs emitted while executing an ERC-4337 UserOperation
+		UserOpSender string `json:"userOpSender,omitempty"`
+	}
+
+	// Tx holds the gas and fee accounting of the transaction the event was derived from
+	Tx struct {
+		GasUsed           uint64 `json:"gasUsed"`
+		EffectiveGasPrice string `json:"effectiveGasPrice"`
+		Fee               string `json:"fee"`
+		FeeCurrency       string `json:"feeCurrency,omitempty"`
+		L1Fee             string `json:"l1Fee,omitempty"`
+	}
+
+	RevertReasonSetter interface {
+		SetRevertReason(string)
 	}
+
+	TokenValuePayload interface {
+		RawValue() string
+		SetTokenMetadata(TokenMetadata, string)
+	}
+)
+
+const (
+	// ContentTypeHeader carries the encoding of a published event
+	ContentTypeHeader   = "Content-Type"
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/protobuf"
 )
 
+// SchemaVersion 2 added positional metadata (eventId, blockHash, transactionIndex, logIndex)
+// SchemaVersion 3 introduced typed payloads, see the schema directory
+// SchemaVersion 4 added callIndex for events derived from input data and userOpSender for UserOperation logs
+const SchemaVersion uint = 4
+
+// ID is stable across reprocessing and doubles as the JetStream deduplication message ID.
+// Events derived from logs and the outer call of a reverted transaction, which has no logs, are keyed by the log index.
+// Other calls are keyed by the call index so that they never collide with a log of the same transaction,
+// block level events without a transaction are keyed by the block hash
+func (e Event) ID() string {
+	switch {
+	case e.TxHash == "":
+		return fmt.Sprintf("%s:%s", e.BlockHash, e.TxType)
+	case e.CallIndex != nil && (e.Success || *e.CallIndex > 0):
+		return fmt.Sprintf("%s:call:%d", e.TxHash, *e.CallIndex)
+	default:
+		return fmt.Sprintf("%s:%d", e.TxHash, e.Index)
+	}
+}
+
 func (e Event) Serialize() ([]byte, error) {
+	e.SchemaVersion = SchemaVersion
+	e.EventID = e.ID()
+
 	jsonData, err := json.Marshal(e)
 	if err != nil {
 		return nil, err
@@ -24,6 +85,57 @@ func (e Event) Serialize() ([]byte, error) {
 	return jsonData, err
 }
 
+// SerializeAs encodes the event with one of the supported content types, see pkg/event/eventpb for the Protobuf definitions
+func (e Event) SerializeAs(contentType string) ([]byte, error) {
+	switch contentType {
+	case ContentTypeJSON:
+		return e.Serialize()
+	case ContentTypeProtobuf:
+		e.SchemaVersion = SchemaVersion
+		e.EventID = e.ID()
+
+		return e.serializeProtobuf()
+	default:
+		return nil, fmt.Errorf("unsupported content type %s", contentType)
+	}
+}
+
+func ValidContentType(contentType string) bool {
+	return contentType == ContentTypeJSON || contentType == ContentTypeProtobuf
+}
+
+// UnmarshalJSON decodes the payload into its typed struct, unknown TxTypes are decoded into a map
+func (e *Event) UnmarshalJSON(jsonData []byte) error {
+	type eventAlias Event
+	var raw struct {
+		eventAlias
+		Payload json.RawMessage `json:"payload"`
+	}
+
+	if err := json.Unmarshal(jsonData, &raw); err != nil {
+		return err
+	}
+	*e = Event(raw.eventAlias)
+
+	payload := NewPayload(e.TxType)
+	if payload == nil {
+		payload = &map[string]any{}
+	}
+	if len(raw.Payload) > 0 && string(raw.Payload) != "null" {
+		if err := json.Unmarshal(raw.Payload, payload); err != nil {
+			return err
+		}
+	}
+
+	if m, ok := payload.(*map[string]any); ok {
+		e.Payload = *m
+	} else {
+		e.Payload = payload
+	}
+
+	return nil
+}
+
 func Deserialize(jsonData []byte) (Event, error) {
 	var (
 		event Event
@@ -35,3 +147,15 @@ func Deserialize(jsonData []byte) (Event, error) {
 
 	return event, nil
 }
+
+// DeserializeAs decodes an event published with the content type, an empty content type is treated as JSON
+func DeserializeAs(data []byte, contentType string) (Event, error) {
+	switch contentType {
+	case "", ContentTypeJSON:
+		return Deserialize(data)
+	case ContentTypeProtobuf:
+		return deserializeProtobuf(data)
+	default:
+		return Event{}, fmt.Errorf("unsupported content type %s", contentType)
+	}
+}
@@ -0,0 +1,55 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvent_ID(t *testing.T) {
+	callIndex := func(i uint) *uint { return &i }
+
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{"Log", Event{TxHash: "0x01", Index: 7, Success: true, TxType: "TOKEN_APPROVE"}, "0x01:7"},
+		{"RevertedOuterCall", Event{TxHash: "0x01", CallIndex: callIndex(0), TxType: "TOKEN_TRANSFER"}, "0x01:0"},
+		{"RevertedInnerCall", Event{TxHash: "0x01", CallIndex: callIndex(2), TxType: "TOKEN_TRANSFER"}, "0x01:call:2"},
+		{"SuccessfulCall", Event{TxHash: "0x01", CallIndex: callIndex(0), Success: true, TxType: "TOKEN_PERMIT"}, "0x01:call:0"},
+		{"Block", Event{BlockHash: "0xb1", TxType: "BLOCK_PROCESSED"}, "0xb1:BLOCK_PROCESSED"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.event.ID())
+		})
+	}
+}
+
+func TestDeserializeAs(t *testing.T) {
+	ev := Event{TxHash: "0x01", Index: 3, Success: true, TxType: "TOKEN_TRANSFER", Payload: &TokenTransferPayload{Value: "10"}}
+
+	for _, contentType := range []string{ContentTypeJSON, ContentTypeProtobuf} {
+		t.Run(contentType, func(t *testing.T) {
+			data, err := ev.SerializeAs(contentType)
+			require.NoError(t, err)
+
+			decoded, err := DeserializeAs(data, contentType)
+			require.NoError(t, err)
+			require.Equal(t, "0x01:3", decoded.EventID)
+			require.Equal(t, &TokenTransferPayload{Value: "10"}, decoded.Payload)
+		})
+	}
+
+	// Messages published without a Content-Type header are JSON
+	jsonData, err := ev.Serialize()
+	require.NoError(t, err)
+	decoded, err := DeserializeAs(jsonData, "")
+	require.NoError(t, err)
+	require.Equal(t, "TOKEN_TRANSFER", decoded.TxType)
+
+	_, err = DeserializeAs([]byte("{}"), "text/plain")
+	require.Error(t, err)
+}
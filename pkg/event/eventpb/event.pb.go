@@ -0,0 +1,2661 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: event.proto
+
+package eventpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Event struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion    uint32                 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	EventId          string                 `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Block            uint64                 `protobuf:"varint,3,opt,name=block,proto3" json:"block,omitempty"`
+	BlockHash        string                 `protobuf:"bytes,4,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	ContractAddress  string                 `protobuf:"bytes,5,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	Success          bool                   `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`
+	Timestamp        uint64                 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	TransactionHash  string                 `protobuf:"bytes,8,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	TransactionIndex uint32                 `protobuf:"varint,9,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	TransactionType  string                 `protobuf:"bytes,10,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type,omitempty"`
+	LogIndex         uint32                 `protobuf:"varint,11,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	Tx               *Tx                    `protobuf:"bytes,12,opt,name=tx,proto3" json:"tx,omitempty"`
+	CallIndex        *uint32                `protobuf:"varint,13,opt,name=call_index,json=callIndex,proto3,oneof" json:"call_index,omitempty"`
+	UserOpSender     string                 `protobuf:"bytes,14,opt,name=user_op_sender,json=userOpSender,proto3" json:"user_op_sender,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Event_ContractCreation
+	//	*Event_CustodialRegistration
+	//	*Event_FaucetGive
+	//	*Event_IndexAdd
+	//	*Event_IndexRemove
+	//	*Event_OwnershipTransferred
+	//	*Event_PoolDeposit
+	//	*Event_PoolSwap
+	//	*Event_QuoterPriceIndexUpdated
+	//	*Event_QuoterUpdated
+	//	*Event_SealStateChange
+	//	*Event_TokenApprove
+	//	*Event_TokenBurn
+	//	*Event_TokenMint
+	//	*Event_TokenTransfer
+	//	*Event_TokenPermit
+	//	*Event_NftTransfer
+	//	*Event_MultiTokenTransfer
+	//	*Event_UserOperation
+	//	*Event_AccountDeployed
+	//	*Event_UserOperationRevertReason
+	//	*Event_BlockProcessed
+	//	*Event_NftApprove
+	//	*Event_ApprovalForAll
+	Payload       isEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_event_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Event) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Event) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Event) GetBlock() uint64 {
+	if x != nil {
+		return x.Block
+	}
+	return 0
+}
+
+func (x *Event) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *Event) GetContractAddress() string {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return ""
+}
+
+func (x *Event) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *Event) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Event) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+func (x *Event) GetTransactionIndex() uint32 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *Event) GetTransactionType() string {
+	if x != nil {
+		return x.TransactionType
+	}
+	return ""
+}
+
+func (x *Event) GetLogIndex() uint32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *Event) GetTx() *Tx {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *Event) GetCallIndex() uint32 {
+	if x != nil && x.CallIndex != nil {
+		return *x.CallIndex
+	}
+	return 0
+}
+
+func (x *Event) GetUserOpSender() string {
+	if x != nil {
+		return x.UserOpSender
+	}
+	return ""
+}
+
+func (x *Event) GetPayload() isEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetContractCreation() *ContractCreation {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ContractCreation); ok {
+			return x.ContractCreation
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetCustodialRegistration() *CustodialRegistration {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_CustodialRegistration); ok {
+			return x.CustodialRegistration
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetFaucetGive() *FaucetGive {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_FaucetGive); ok {
+			return x.FaucetGive
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetIndexAdd() *IndexAdd {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_IndexAdd); ok {
+			return x.IndexAdd
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetIndexRemove() *IndexRemove {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_IndexRemove); ok {
+			return x.IndexRemove
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetOwnershipTransferred() *OwnershipTransferred {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_OwnershipTransferred); ok {
+			return x.OwnershipTransferred
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPoolDeposit() *PoolDeposit {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_PoolDeposit); ok {
+			return x.PoolDeposit
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetPoolSwap() *PoolSwap {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_PoolSwap); ok {
+			return x.PoolSwap
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetQuoterPriceIndexUpdated() *QuoterPriceIndexUpdated {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_QuoterPriceIndexUpdated); ok {
+			return x.QuoterPriceIndexUpdated
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetQuoterUpdated() *QuoterUpdated {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_QuoterUpdated); ok {
+			return x.QuoterUpdated
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetSealStateChange() *SealStateChange {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_SealStateChange); ok {
+			return x.SealStateChange
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTokenApprove() *TokenApprove {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TokenApprove); ok {
+			return x.TokenApprove
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTokenBurn() *TokenBurn {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TokenBurn); ok {
+			return x.TokenBurn
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTokenMint() *TokenMint {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TokenMint); ok {
+			return x.TokenMint
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTokenTransfer() *TokenTransfer {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TokenTransfer); ok {
+			return x.TokenTransfer
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTokenPermit() *TokenPermit {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_TokenPermit); ok {
+			return x.TokenPermit
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetNftTransfer() *NFTTransfer {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_NftTransfer); ok {
+			return x.NftTransfer
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetMultiTokenTransfer() *MultiTokenTransfer {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_MultiTokenTransfer); ok {
+			return x.MultiTokenTransfer
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetUserOperation() *UserOperation {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_UserOperation); ok {
+			return x.UserOperation
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetAccountDeployed() *AccountDeployed {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_AccountDeployed); ok {
+			return x.AccountDeployed
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetUserOperationRevertReason() *UserOperationRevertReason {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_UserOperationRevertReason); ok {
+			return x.UserOperationRevertReason
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetBlockProcessed() *BlockProcessed {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_BlockProcessed); ok {
+			return x.BlockProcessed
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetNftApprove() *NFTApprove {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_NftApprove); ok {
+			return x.NftApprove
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetApprovalForAll() *ApprovalForAll {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_ApprovalForAll); ok {
+			return x.ApprovalForAll
+		}
+	}
+	return nil
+}
+
+type isEvent_Payload interface {
+	isEvent_Payload()
+}
+
+type Event_ContractCreation struct {
+	ContractCreation *ContractCreation `protobuf:"bytes,20,opt,name=contract_creation,json=contractCreation,proto3,oneof"`
+}
+
+type Event_CustodialRegistration struct {
+	CustodialRegistration *CustodialRegistration `protobuf:"bytes,21,opt,name=custodial_registration,json=custodialRegistration,proto3,oneof"`
+}
+
+type Event_FaucetGive struct {
+	FaucetGive *FaucetGive `protobuf:"bytes,22,opt,name=faucet_give,json=faucetGive,proto3,oneof"`
+}
+
+type Event_IndexAdd struct {
+	IndexAdd *IndexAdd `protobuf:"bytes,23,opt,name=index_add,json=indexAdd,proto3,oneof"`
+}
+
+type Event_IndexRemove struct {
+	IndexRemove *IndexRemove `protobuf:"bytes,24,opt,name=index_remove,json=indexRemove,proto3,oneof"`
+}
+
+type Event_OwnershipTransferred struct {
+	OwnershipTransferred *OwnershipTransferred `protobuf:"bytes,25,opt,name=ownership_transferred,json=ownershipTransferred,proto3,oneof"`
+}
+
+type Event_PoolDeposit struct {
+	PoolDeposit *PoolDeposit `protobuf:"bytes,26,opt,name=pool_deposit,json=poolDeposit,proto3,oneof"`
+}
+
+type Event_PoolSwap struct {
+	PoolSwap *PoolSwap `protobuf:"bytes,27,opt,name=pool_swap,json=poolSwap,proto3,oneof"`
+}
+
+type Event_QuoterPriceIndexUpdated struct {
+	QuoterPriceIndexUpdated *QuoterPriceIndexUpdated `protobuf:"bytes,28,opt,name=quoter_price_index_updated,json=quoterPriceIndexUpdated,proto3,oneof"`
+}
+
+type Event_QuoterUpdated struct {
+	QuoterUpdated *QuoterUpdated `protobuf:"bytes,29,opt,name=quoter_updated,json=quoterUpdated,proto3,oneof"`
+}
+
+type Event_SealStateChange struct {
+	SealStateChange *SealStateChange `protobuf:"bytes,30,opt,name=seal_state_change,json=sealStateChange,proto3,oneof"`
+}
+
+type Event_TokenApprove struct {
+	TokenApprove *TokenApprove `protobuf:"bytes,31,opt,name=token_approve,json=tokenApprove,proto3,oneof"`
+}
+
+type Event_TokenBurn struct {
+	TokenBurn *TokenBurn `protobuf:"bytes,32,opt,name=token_burn,json=tokenBurn,proto3,oneof"`
+}
+
+type Event_TokenMint struct {
+	TokenMint *TokenMint `protobuf:"bytes,33,opt,name=token_mint,json=tokenMint,proto3,oneof"`
+}
+
+type Event_TokenTransfer struct {
+	TokenTransfer *TokenTransfer `protobuf:"bytes,34,opt,name=token_transfer,json=tokenTransfer,proto3,oneof"`
+}
+
+type Event_TokenPermit struct {
+	TokenPermit *TokenPermit `protobuf:"bytes,35,opt,name=token_permit,json=tokenPermit,proto3,oneof"`
+}
+
+type Event_NftTransfer struct {
+	NftTransfer *NFTTransfer `protobuf:"bytes,36,opt,name=nft_transfer,json=nftTransfer,proto3,oneof"`
+}
+
+type Event_MultiTokenTransfer struct {
+	MultiTokenTransfer *MultiTokenTransfer `protobuf:"bytes,37,opt,name=multi_token_transfer,json=multiTokenTransfer,proto3,oneof"`
+}
+
+type Event_UserOperation struct {
+	UserOperation *UserOperation `protobuf:"bytes,38,opt,name=user_operation,json=userOperation,proto3,oneof"`
+}
+
+type Event_AccountDeployed struct {
+	AccountDeployed *AccountDeployed `protobuf:"bytes,39,opt,name=account_deployed,json=accountDeployed,proto3,oneof"`
+}
+
+type Event_UserOperationRevertReason struct {
+	UserOperationRevertReason *UserOperationRevertReason `protobuf:"bytes,40,opt,name=user_operation_revert_reason,json=userOperationRevertReason,proto3,oneof"`
+}
+
+type Event_BlockProcessed struct {
+	BlockProcessed *BlockProcessed `protobuf:"bytes,41,opt,name=block_processed,json=blockProcessed,proto3,oneof"`
+}
+
+type Event_NftApprove struct {
+	NftApprove *NFTApprove `protobuf:"bytes,42,opt,name=nft_approve,json=nftApprove,proto3,oneof"`
+}
+
+type Event_ApprovalForAll struct {
+	ApprovalForAll *ApprovalForAll `protobuf:"bytes,43,opt,name=approval_for_all,json=approvalForAll,proto3,oneof"`
+}
+
+func (*Event_ContractCreation) isEvent_Payload() {}
+
+func (*Event_CustodialRegistration) isEvent_Payload() {}
+
+func (*Event_FaucetGive) isEvent_Payload() {}
+
+func (*Event_IndexAdd) isEvent_Payload() {}
+
+func (*Event_IndexRemove) isEvent_Payload() {}
+
+func (*Event_OwnershipTransferred) isEvent_Payload() {}
+
+func (*Event_PoolDeposit) isEvent_Payload() {}
+
+func (*Event_PoolSwap) isEvent_Payload() {}
+
+func (*Event_QuoterPriceIndexUpdated) isEvent_Payload() {}
+
+func (*Event_QuoterUpdated) isEvent_Payload() {}
+
+func (*Event_SealStateChange) isEvent_Payload() {}
+
+func (*Event_TokenApprove) isEvent_Payload() {}
+
+func (*Event_TokenBurn) isEvent_Payload() {}
+
+func (*Event_TokenMint) isEvent_Payload() {}
+
+func (*Event_TokenTransfer) isEvent_Payload() {}
+
+func (*Event_TokenPermit) isEvent_Payload() {}
+
+func (*Event_NftTransfer) isEvent_Payload() {}
+
+func (*Event_MultiTokenTransfer) isEvent_Payload() {}
+
+func (*Event_UserOperation) isEvent_Payload() {}
+
+func (*Event_AccountDeployed) isEvent_Payload() {}
+
+func (*Event_UserOperationRevertReason) isEvent_Payload() {}
+
+func (*Event_BlockProcessed) isEvent_Payload() {}
+
+func (*Event_NftApprove) isEvent_Payload() {}
+
+func (*Event_ApprovalForAll) isEvent_Payload() {}
+
+type Tx struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GasUsed           uint64                 `protobuf:"varint,1,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	EffectiveGasPrice string                 `protobuf:"bytes,2,opt,name=effective_gas_price,json=effectiveGasPrice,proto3" json:"effective_gas_price,omitempty"`
+	Fee               string                 `protobuf:"bytes,3,opt,name=fee,proto3" json:"fee,omitempty"`
+	FeeCurrency       string                 `protobuf:"bytes,4,opt,name=fee_currency,json=feeCurrency,proto3" json:"fee_currency,omitempty"`
+	L1Fee             string                 `protobuf:"bytes,5,opt,name=l1_fee,json=l1Fee,proto3" json:"l1_fee,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Tx) Reset() {
+	*x = Tx{}
+	mi := &file_event_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tx) ProtoMessage() {}
+
+func (x *Tx) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tx.ProtoReflect.Descriptor instead.
+func (*Tx) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Tx) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *Tx) GetEffectiveGasPrice() string {
+	if x != nil {
+		return x.EffectiveGasPrice
+	}
+	return ""
+}
+
+func (x *Tx) GetFee() string {
+	if x != nil {
+		return x.Fee
+	}
+	return ""
+}
+
+func (x *Tx) GetFeeCurrency() string {
+	if x != nil {
+		return x.FeeCurrency
+	}
+	return ""
+}
+
+func (x *Tx) GetL1Fee() string {
+	if x != nil {
+		return x.L1Fee
+	}
+	return ""
+}
+
+type TokenMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Decimals      uint32                 `protobuf:"varint,3,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenMetadata) Reset() {
+	*x = TokenMetadata{}
+	mi := &file_event_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenMetadata) ProtoMessage() {}
+
+func (x *TokenMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenMetadata.ProtoReflect.Descriptor instead.
+func (*TokenMetadata) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TokenMetadata) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TokenMetadata) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TokenMetadata) GetDecimals() uint32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+type ContractCreation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContractCreation) Reset() {
+	*x = ContractCreation{}
+	mi := &file_event_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContractCreation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractCreation) ProtoMessage() {}
+
+func (x *ContractCreation) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractCreation.ProtoReflect.Descriptor instead.
+func (*ContractCreation) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ContractCreation) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ContractCreation) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type CustodialRegistration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustodialRegistration) Reset() {
+	*x = CustodialRegistration{}
+	mi := &file_event_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustodialRegistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustodialRegistration) ProtoMessage() {}
+
+func (x *CustodialRegistration) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustodialRegistration.ProtoReflect.Descriptor instead.
+func (*CustodialRegistration) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CustodialRegistration) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *CustodialRegistration) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type FaucetGive struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipient     string                 `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Amount        string                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FaucetGive) Reset() {
+	*x = FaucetGive{}
+	mi := &file_event_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FaucetGive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaucetGive) ProtoMessage() {}
+
+func (x *FaucetGive) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaucetGive.ProtoReflect.Descriptor instead.
+func (*FaucetGive) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FaucetGive) GetRecipient() string {
+	if x != nil {
+		return x.Recipient
+	}
+	return ""
+}
+
+func (x *FaucetGive) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *FaucetGive) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *FaucetGive) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type IndexAdd struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexAdd) Reset() {
+	*x = IndexAdd{}
+	mi := &file_event_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexAdd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexAdd) ProtoMessage() {}
+
+func (x *IndexAdd) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexAdd.ProtoReflect.Descriptor instead.
+func (*IndexAdd) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IndexAdd) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *IndexAdd) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type IndexRemove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexRemove) Reset() {
+	*x = IndexRemove{}
+	mi := &file_event_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexRemove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexRemove) ProtoMessage() {}
+
+func (x *IndexRemove) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexRemove.ProtoReflect.Descriptor instead.
+func (*IndexRemove) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *IndexRemove) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *IndexRemove) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type OwnershipTransferred struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PreviousOwner string                 `protobuf:"bytes,1,opt,name=previous_owner,json=previousOwner,proto3" json:"previous_owner,omitempty"`
+	NewOwner      string                 `protobuf:"bytes,2,opt,name=new_owner,json=newOwner,proto3" json:"new_owner,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OwnershipTransferred) Reset() {
+	*x = OwnershipTransferred{}
+	mi := &file_event_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OwnershipTransferred) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnershipTransferred) ProtoMessage() {}
+
+func (x *OwnershipTransferred) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnershipTransferred.ProtoReflect.Descriptor instead.
+func (*OwnershipTransferred) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OwnershipTransferred) GetPreviousOwner() string {
+	if x != nil {
+		return x.PreviousOwner
+	}
+	return ""
+}
+
+func (x *OwnershipTransferred) GetNewOwner() string {
+	if x != nil {
+		return x.NewOwner
+	}
+	return ""
+}
+
+func (x *OwnershipTransferred) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type PoolDeposit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Initiator     string                 `protobuf:"bytes,1,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	TokenIn       string                 `protobuf:"bytes,2,opt,name=token_in,json=tokenIn,proto3" json:"token_in,omitempty"`
+	AmountIn      string                 `protobuf:"bytes,3,opt,name=amount_in,json=amountIn,proto3" json:"amount_in,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolDeposit) Reset() {
+	*x = PoolDeposit{}
+	mi := &file_event_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolDeposit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolDeposit) ProtoMessage() {}
+
+func (x *PoolDeposit) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolDeposit.ProtoReflect.Descriptor instead.
+func (*PoolDeposit) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PoolDeposit) GetInitiator() string {
+	if x != nil {
+		return x.Initiator
+	}
+	return ""
+}
+
+func (x *PoolDeposit) GetTokenIn() string {
+	if x != nil {
+		return x.TokenIn
+	}
+	return ""
+}
+
+func (x *PoolDeposit) GetAmountIn() string {
+	if x != nil {
+		return x.AmountIn
+	}
+	return ""
+}
+
+func (x *PoolDeposit) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type PoolSwap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Initiator     string                 `protobuf:"bytes,1,opt,name=initiator,proto3" json:"initiator,omitempty"`
+	TokenIn       string                 `protobuf:"bytes,2,opt,name=token_in,json=tokenIn,proto3" json:"token_in,omitempty"`
+	TokenOut      string                 `protobuf:"bytes,3,opt,name=token_out,json=tokenOut,proto3" json:"token_out,omitempty"`
+	AmountIn      string                 `protobuf:"bytes,4,opt,name=amount_in,json=amountIn,proto3" json:"amount_in,omitempty"`
+	AmountOut     string                 `protobuf:"bytes,5,opt,name=amount_out,json=amountOut,proto3" json:"amount_out,omitempty"`
+	Fee           string                 `protobuf:"bytes,6,opt,name=fee,proto3" json:"fee,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PoolSwap) Reset() {
+	*x = PoolSwap{}
+	mi := &file_event_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PoolSwap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolSwap) ProtoMessage() {}
+
+func (x *PoolSwap) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolSwap.ProtoReflect.Descriptor instead.
+func (*PoolSwap) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PoolSwap) GetInitiator() string {
+	if x != nil {
+		return x.Initiator
+	}
+	return ""
+}
+
+func (x *PoolSwap) GetTokenIn() string {
+	if x != nil {
+		return x.TokenIn
+	}
+	return ""
+}
+
+func (x *PoolSwap) GetTokenOut() string {
+	if x != nil {
+		return x.TokenOut
+	}
+	return ""
+}
+
+func (x *PoolSwap) GetAmountIn() string {
+	if x != nil {
+		return x.AmountIn
+	}
+	return ""
+}
+
+func (x *PoolSwap) GetAmountOut() string {
+	if x != nil {
+		return x.AmountOut
+	}
+	return ""
+}
+
+func (x *PoolSwap) GetFee() string {
+	if x != nil {
+		return x.Fee
+	}
+	return ""
+}
+
+func (x *PoolSwap) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type QuoterPriceIndexUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExchangeRate  string                 `protobuf:"bytes,2,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoterPriceIndexUpdated) Reset() {
+	*x = QuoterPriceIndexUpdated{}
+	mi := &file_event_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoterPriceIndexUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoterPriceIndexUpdated) ProtoMessage() {}
+
+func (x *QuoterPriceIndexUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoterPriceIndexUpdated.ProtoReflect.Descriptor instead.
+func (*QuoterPriceIndexUpdated) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QuoterPriceIndexUpdated) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *QuoterPriceIndexUpdated) GetExchangeRate() string {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return ""
+}
+
+func (x *QuoterPriceIndexUpdated) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type QuoterUpdated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewQuoter     string                 `protobuf:"bytes,1,opt,name=new_quoter,json=newQuoter,proto3" json:"new_quoter,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoterUpdated) Reset() {
+	*x = QuoterUpdated{}
+	mi := &file_event_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoterUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoterUpdated) ProtoMessage() {}
+
+func (x *QuoterUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoterUpdated.ProtoReflect.Descriptor instead.
+func (*QuoterUpdated) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *QuoterUpdated) GetNewQuoter() string {
+	if x != nil {
+		return x.NewQuoter
+	}
+	return ""
+}
+
+func (x *QuoterUpdated) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type SealStateChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Final         *bool                  `protobuf:"varint,1,opt,name=final,proto3,oneof" json:"final,omitempty"`
+	SealState     string                 `protobuf:"bytes,2,opt,name=seal_state,json=sealState,proto3" json:"seal_state,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SealStateChange) Reset() {
+	*x = SealStateChange{}
+	mi := &file_event_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SealStateChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SealStateChange) ProtoMessage() {}
+
+func (x *SealStateChange) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SealStateChange.ProtoReflect.Descriptor instead.
+func (*SealStateChange) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SealStateChange) GetFinal() bool {
+	if x != nil && x.Final != nil {
+		return *x.Final
+	}
+	return false
+}
+
+func (x *SealStateChange) GetSealState() string {
+	if x != nil {
+		return x.SealState
+	}
+	return ""
+}
+
+func (x *SealStateChange) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type TokenApprove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Spender       string                 `protobuf:"bytes,2,opt,name=spender,proto3" json:"spender,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenApprove) Reset() {
+	*x = TokenApprove{}
+	mi := &file_event_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenApprove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenApprove) ProtoMessage() {}
+
+func (x *TokenApprove) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenApprove.ProtoReflect.Descriptor instead.
+func (*TokenApprove) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TokenApprove) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *TokenApprove) GetSpender() string {
+	if x != nil {
+		return x.Spender
+	}
+	return ""
+}
+
+func (x *TokenApprove) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TokenApprove) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type TokenBurn struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TokenBurner     string                 `protobuf:"bytes,1,opt,name=token_burner,json=tokenBurner,proto3" json:"token_burner,omitempty"`
+	Value           string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Token           *TokenMetadata         `protobuf:"bytes,13,opt,name=token,proto3" json:"token,omitempty"`
+	NormalizedValue string                 `protobuf:"bytes,14,opt,name=normalized_value,json=normalizedValue,proto3" json:"normalized_value,omitempty"`
+	RevertReason    string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TokenBurn) Reset() {
+	*x = TokenBurn{}
+	mi := &file_event_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenBurn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenBurn) ProtoMessage() {}
+
+func (x *TokenBurn) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenBurn.ProtoReflect.Descriptor instead.
+func (*TokenBurn) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TokenBurn) GetTokenBurner() string {
+	if x != nil {
+		return x.TokenBurner
+	}
+	return ""
+}
+
+func (x *TokenBurn) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TokenBurn) GetToken() *TokenMetadata {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *TokenBurn) GetNormalizedValue() string {
+	if x != nil {
+		return x.NormalizedValue
+	}
+	return ""
+}
+
+func (x *TokenBurn) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type TokenMint struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TokenMinter     string                 `protobuf:"bytes,1,opt,name=token_minter,json=tokenMinter,proto3" json:"token_minter,omitempty"`
+	To              string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Value           string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Token           *TokenMetadata         `protobuf:"bytes,13,opt,name=token,proto3" json:"token,omitempty"`
+	NormalizedValue string                 `protobuf:"bytes,14,opt,name=normalized_value,json=normalizedValue,proto3" json:"normalized_value,omitempty"`
+	RevertReason    string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TokenMint) Reset() {
+	*x = TokenMint{}
+	mi := &file_event_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenMint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenMint) ProtoMessage() {}
+
+func (x *TokenMint) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenMint.ProtoReflect.Descriptor instead.
+func (*TokenMint) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TokenMint) GetTokenMinter() string {
+	if x != nil {
+		return x.TokenMinter
+	}
+	return ""
+}
+
+func (x *TokenMint) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TokenMint) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TokenMint) GetToken() *TokenMetadata {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *TokenMint) GetNormalizedValue() string {
+	if x != nil {
+		return x.NormalizedValue
+	}
+	return ""
+}
+
+func (x *TokenMint) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type TokenTransfer struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	From            string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To              string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Spender         string                 `protobuf:"bytes,3,opt,name=spender,proto3" json:"spender,omitempty"`
+	Value           string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Token           *TokenMetadata         `protobuf:"bytes,13,opt,name=token,proto3" json:"token,omitempty"`
+	NormalizedValue string                 `protobuf:"bytes,14,opt,name=normalized_value,json=normalizedValue,proto3" json:"normalized_value,omitempty"`
+	RevertReason    string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TokenTransfer) Reset() {
+	*x = TokenTransfer{}
+	mi := &file_event_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenTransfer) ProtoMessage() {}
+
+func (x *TokenTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenTransfer.ProtoReflect.Descriptor instead.
+func (*TokenTransfer) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TokenTransfer) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetSpender() string {
+	if x != nil {
+		return x.Spender
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetToken() *TokenMetadata {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *TokenTransfer) GetNormalizedValue() string {
+	if x != nil {
+		return x.NormalizedValue
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type TokenPermit struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Owner            string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Spender          string                 `protobuf:"bytes,2,opt,name=spender,proto3" json:"spender,omitempty"`
+	Value            string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Deadline         string                 `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Nonce            string                 `protobuf:"bytes,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	ApprovalLogIndex *uint32                `protobuf:"varint,6,opt,name=approval_log_index,json=approvalLogIndex,proto3,oneof" json:"approval_log_index,omitempty"`
+	RevertReason     string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *TokenPermit) Reset() {
+	*x = TokenPermit{}
+	mi := &file_event_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenPermit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenPermit) ProtoMessage() {}
+
+func (x *TokenPermit) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenPermit.ProtoReflect.Descriptor instead.
+func (*TokenPermit) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TokenPermit) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *TokenPermit) GetSpender() string {
+	if x != nil {
+		return x.Spender
+	}
+	return ""
+}
+
+func (x *TokenPermit) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TokenPermit) GetDeadline() string {
+	if x != nil {
+		return x.Deadline
+	}
+	return ""
+}
+
+func (x *TokenPermit) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *TokenPermit) GetApprovalLogIndex() uint32 {
+	if x != nil && x.ApprovalLogIndex != nil {
+		return *x.ApprovalLogIndex
+	}
+	return 0
+}
+
+func (x *TokenPermit) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type NFTTransfer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	TokenId       string                 `protobuf:"bytes,3,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NFTTransfer) Reset() {
+	*x = NFTTransfer{}
+	mi := &file_event_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NFTTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NFTTransfer) ProtoMessage() {}
+
+func (x *NFTTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NFTTransfer.ProtoReflect.Descriptor instead.
+func (*NFTTransfer) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *NFTTransfer) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *NFTTransfer) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *NFTTransfer) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *NFTTransfer) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type MultiTokenTransfer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operator      string                 `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	TokenIds      []string               `protobuf:"bytes,4,rep,name=token_ids,json=tokenIds,proto3" json:"token_ids,omitempty"`
+	Values        []string               `protobuf:"bytes,5,rep,name=values,proto3" json:"values,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MultiTokenTransfer) Reset() {
+	*x = MultiTokenTransfer{}
+	mi := &file_event_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MultiTokenTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiTokenTransfer) ProtoMessage() {}
+
+func (x *MultiTokenTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiTokenTransfer.ProtoReflect.Descriptor instead.
+func (*MultiTokenTransfer) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MultiTokenTransfer) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *MultiTokenTransfer) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *MultiTokenTransfer) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *MultiTokenTransfer) GetTokenIds() []string {
+	if x != nil {
+		return x.TokenIds
+	}
+	return nil
+}
+
+func (x *MultiTokenTransfer) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *MultiTokenTransfer) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type UserOperation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserOpHash    string                 `protobuf:"bytes,1,opt,name=user_op_hash,json=userOpHash,proto3" json:"user_op_hash,omitempty"`
+	Sender        string                 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Paymaster     string                 `protobuf:"bytes,3,opt,name=paymaster,proto3" json:"paymaster,omitempty"`
+	Nonce         string                 `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	ActualGasCost string                 `protobuf:"bytes,5,opt,name=actual_gas_cost,json=actualGasCost,proto3" json:"actual_gas_cost,omitempty"`
+	ActualGasUsed string                 `protobuf:"bytes,6,opt,name=actual_gas_used,json=actualGasUsed,proto3" json:"actual_gas_used,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserOperation) Reset() {
+	*x = UserOperation{}
+	mi := &file_event_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserOperation) ProtoMessage() {}
+
+func (x *UserOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserOperation.ProtoReflect.Descriptor instead.
+func (*UserOperation) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UserOperation) GetUserOpHash() string {
+	if x != nil {
+		return x.UserOpHash
+	}
+	return ""
+}
+
+func (x *UserOperation) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *UserOperation) GetPaymaster() string {
+	if x != nil {
+		return x.Paymaster
+	}
+	return ""
+}
+
+func (x *UserOperation) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *UserOperation) GetActualGasCost() string {
+	if x != nil {
+		return x.ActualGasCost
+	}
+	return ""
+}
+
+func (x *UserOperation) GetActualGasUsed() string {
+	if x != nil {
+		return x.ActualGasUsed
+	}
+	return ""
+}
+
+type AccountDeployed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserOpHash    string                 `protobuf:"bytes,1,opt,name=user_op_hash,json=userOpHash,proto3" json:"user_op_hash,omitempty"`
+	Sender        string                 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Factory       string                 `protobuf:"bytes,3,opt,name=factory,proto3" json:"factory,omitempty"`
+	Paymaster     string                 `protobuf:"bytes,4,opt,name=paymaster,proto3" json:"paymaster,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountDeployed) Reset() {
+	*x = AccountDeployed{}
+	mi := &file_event_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDeployed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDeployed) ProtoMessage() {}
+
+func (x *AccountDeployed) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDeployed.ProtoReflect.Descriptor instead.
+func (*AccountDeployed) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AccountDeployed) GetUserOpHash() string {
+	if x != nil {
+		return x.UserOpHash
+	}
+	return ""
+}
+
+func (x *AccountDeployed) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *AccountDeployed) GetFactory() string {
+	if x != nil {
+		return x.Factory
+	}
+	return ""
+}
+
+func (x *AccountDeployed) GetPaymaster() string {
+	if x != nil {
+		return x.Paymaster
+	}
+	return ""
+}
+
+type UserOperationRevertReason struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserOpHash    string                 `protobuf:"bytes,1,opt,name=user_op_hash,json=userOpHash,proto3" json:"user_op_hash,omitempty"`
+	Sender        string                 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Nonce         string                 `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,4,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserOperationRevertReason) Reset() {
+	*x = UserOperationRevertReason{}
+	mi := &file_event_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserOperationRevertReason) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserOperationRevertReason) ProtoMessage() {}
+
+func (x *UserOperationRevertReason) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserOperationRevertReason.ProtoReflect.Descriptor instead.
+func (*UserOperationRevertReason) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UserOperationRevertReason) GetUserOpHash() string {
+	if x != nil {
+		return x.UserOpHash
+	}
+	return ""
+}
+
+func (x *UserOperationRevertReason) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *UserOperationRevertReason) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *UserOperationRevertReason) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type BlockProcessed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventCount    uint64                 `protobuf:"varint,1,opt,name=event_count,json=eventCount,proto3" json:"event_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockProcessed) Reset() {
+	*x = BlockProcessed{}
+	mi := &file_event_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockProcessed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockProcessed) ProtoMessage() {}
+
+func (x *BlockProcessed) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockProcessed.ProtoReflect.Descriptor instead.
+func (*BlockProcessed) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BlockProcessed) GetEventCount() uint64 {
+	if x != nil {
+		return x.EventCount
+	}
+	return 0
+}
+
+type NFTApprove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Approved      string                 `protobuf:"bytes,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	TokenId       string                 `protobuf:"bytes,3,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NFTApprove) Reset() {
+	*x = NFTApprove{}
+	mi := &file_event_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NFTApprove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NFTApprove) ProtoMessage() {}
+
+func (x *NFTApprove) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NFTApprove.ProtoReflect.Descriptor instead.
+func (*NFTApprove) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *NFTApprove) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *NFTApprove) GetApproved() string {
+	if x != nil {
+		return x.Approved
+	}
+	return ""
+}
+
+func (x *NFTApprove) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *NFTApprove) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+type ApprovalForAll struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Approved      bool                   `protobuf:"varint,3,opt,name=approved,proto3" json:"approved,omitempty"`
+	RevertReason  string                 `protobuf:"bytes,15,opt,name=revert_reason,json=revertReason,proto3" json:"revert_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalForAll) Reset() {
+	*x = ApprovalForAll{}
+	mi := &file_event_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalForAll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalForAll) ProtoMessage() {}
+
+func (x *ApprovalForAll) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalForAll.ProtoReflect.Descriptor instead.
+func (*ApprovalForAll) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ApprovalForAll) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ApprovalForAll) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ApprovalForAll) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ApprovalForAll) GetRevertReason() string {
+	if x != nil {
+		return x.RevertReason
+	}
+	return ""
+}
+
+var File_event_proto protoreflect.FileDescriptor
+
+const file_event_proto_rawDesc = "" +
+	"\n" +
+	"\vevent.proto\x12\x10tracker.event.v1\"\xb8\x12\n" +
+	"\x05Event\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\rR\rschemaVersion\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x14\n" +
+	"\x05block\x18\x03 \x01(\x04R\x05block\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x04 \x01(\tR\tblockHash\x12)\n" +
+	"\x10contract_address\x18\x05 \x01(\tR\x0fcontractAddress\x12\x18\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x04R\ttimestamp\x12)\n" +
+	"\x10transaction_hash\x18\b \x01(\tR\x0ftransactionHash\x12+\n" +
+	"\x11transaction_index\x18\t \x01(\rR\x10transactionIndex\x12)\n" +
+	"\x10transaction_type\x18\n" +
+	" \x01(\tR\x0ftransactionType\x12\x1b\n" +
+	"\tlog_index\x18\v \x01(\rR\blogIndex\x12$\n" +
+	"\x02tx\x18\f \x01(\v2\x14.tracker.event.v1.TxR\x02tx\x12\"\n" +
+	"\n" +
+	"call_index\x18\r \x01(\rH\x01R\tcallIndex\x88\x01\x01\x12$\n" +
+	"\x0euser_op_sender\x18\x0e \x01(\tR\fuserOpSender\x12Q\n" +
+	"\x11contract_creation\x18\x14 \x01(\v2\".tracker.event.v1.ContractCreationH\x00R\x10contractCreation\x12`\n" +
+	"\x16custodial_registration\x18\x15 \x01(\v2'.tracker.event.v1.CustodialRegistrationH\x00R\x15custodialRegistration\x12?\n" +
+	"\vfaucet_give\x18\x16 \x01(\v2\x1c.tracker.event.v1.FaucetGiveH\x00R\n" +
+	"faucetGive\x129\n" +
+	"\tindex_add\x18\x17 \x01(\v2\x1a.tracker.event.v1.IndexAddH\x00R\bindexAdd\x12B\n" +
+	"\findex_remove\x18\x18 \x01(\v2\x1d.tracker.event.v1.IndexRemoveH\x00R\vindexRemove\x12]\n" +
+	"\x15ownership_transferred\x18\x19 \x01(\v2&.tracker.event.v1.OwnershipTransferredH\x00R\x14ownershipTransferred\x12B\n" +
+	"\fpool_deposit\x18\x1a \x01(\v2\x1d.tracker.event.v1.PoolDepositH\x00R\vpoolDeposit\x129\n" +
+	"\tpool_swap\x18\x1b \x01(\v2\x1a.tracker.event.v1.PoolSwapH\x00R\bpoolSwap\x12h\n" +
+	"\x1aquoter_price_index_updated\x18\x1c \x01(\v2).tracker.event.v1.QuoterPriceIndexUpdatedH\x00R\x17quoterPriceIndexUpdated\x12H\n" +
+	"\x0equoter_updated\x18\x1d \x01(\v2\x1f.tracker.event.v1.QuoterUpdatedH\x00R\rquoterUpdated\x12O\n" +
+	"\x11seal_state_change\x18\x1e \x01(\v2!.tracker.event.v1.SealStateChangeH\x00R\x0fsealStateChange\x12E\n" +
+	"\rtoken_approve\x18\x1f \x01(\v2\x1e.tracker.event.v1.TokenApproveH\x00R\ftokenApprove\x12<\n" +
+	"\n" +
+	"token_burn\x18  \x01(\v2\x1b.tracker.event.v1.TokenBurnH\x00R\ttokenBurn\x12<\n" +
+	"\n" +
+	"token_mint\x18! \x01(\v2\x1b.tracker.event.v1.TokenMintH\x00R\ttokenMint\x12H\n" +
+	"\x0etoken_transfer\x18\" \x01(\v2\x1f.tracker.event.v1.TokenTransferH\x00R\rtokenTransfer\x12B\n" +
+	"\ftoken_permit\x18# \x01(\v2\x1d.tracker.event.v1.TokenPermitH\x00R\vtokenPermit\x12B\n" +
+	"\fnft_transfer\x18$ \x01(\v2\x1d.tracker.event.v1.NFTTransferH\x00R\vnftTransfer\x12X\n" +
+	"\x14multi_token_transfer\x18% \x01(\v2$.tracker.event.v1.MultiTokenTransferH\x00R\x12multiTokenTransfer\x12H\n" +
+	"\x0euser_operation\x18& \x01(\v2\x1f.tracker.event.v1.UserOperationH\x00R\ruserOperation\x12N\n" +
+	"\x10account_deployed\x18' \x01(\v2!.tracker.event.v1.AccountDeployedH\x00R\x0faccountDeployed\x12n\n" +
+	"\x1cuser_operation_revert_reason\x18( \x01(\v2+.tracker.event.v1.UserOperationRevertReasonH\x00R\x19userOperationRevertReason\x12K\n" +
+	"\x0fblock_processed\x18) \x01(\v2 .tracker.event.v1.BlockProcessedH\x00R\x0eblockProcessed\x12?\n" +
+	"\vnft_approve\x18* \x01(\v2\x1c.tracker.event.v1.NFTApproveH\x00R\n" +
+	"nftApprove\x12L\n" +
+	"\x10approval_for_all\x18+ \x01(\v2 .tracker.event.v1.ApprovalForAllH\x00R\x0eapprovalForAllB\t\n" +
+	"\apayloadB\r\n" +
+	"\v_call_index\"\x9b\x01\n" +
+	"\x02Tx\x12\x19\n" +
+	"\bgas_used\x18\x01 \x01(\x04R\agasUsed\x12.\n" +
+	"\x13effective_gas_price\x18\x02 \x01(\tR\x11effectiveGasPrice\x12\x10\n" +
+	"\x03fee\x18\x03 \x01(\tR\x03fee\x12!\n" +
+	"\ffee_currency\x18\x04 \x01(\tR\vfeeCurrency\x12\x15\n" +
+	"\x06l1_fee\x18\x05 \x01(\tR\x05l1Fee\"W\n" +
+	"\rTokenMetadata\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1a\n" +
+	"\bdecimals\x18\x03 \x01(\rR\bdecimals\"K\n" +
+	"\x10ContractCreation\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"V\n" +
+	"\x15CustodialRegistration\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"}\n" +
+	"\n" +
+	"FaucetGive\x12\x1c\n" +
+	"\trecipient\x18\x01 \x01(\tR\trecipient\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\tR\x06amount\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"I\n" +
+	"\bIndexAdd\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"L\n" +
+	"\vIndexRemove\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"\x7f\n" +
+	"\x14OwnershipTransferred\x12%\n" +
+	"\x0eprevious_owner\x18\x01 \x01(\tR\rpreviousOwner\x12\x1b\n" +
+	"\tnew_owner\x18\x02 \x01(\tR\bnewOwner\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"\x88\x01\n" +
+	"\vPoolDeposit\x12\x1c\n" +
+	"\tinitiator\x18\x01 \x01(\tR\tinitiator\x12\x19\n" +
+	"\btoken_in\x18\x02 \x01(\tR\atokenIn\x12\x1b\n" +
+	"\tamount_in\x18\x03 \x01(\tR\bamountIn\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"\xd3\x01\n" +
+	"\bPoolSwap\x12\x1c\n" +
+	"\tinitiator\x18\x01 \x01(\tR\tinitiator\x12\x19\n" +
+	"\btoken_in\x18\x02 \x01(\tR\atokenIn\x12\x1b\n" +
+	"\ttoken_out\x18\x03 \x01(\tR\btokenOut\x12\x1b\n" +
+	"\tamount_in\x18\x04 \x01(\tR\bamountIn\x12\x1d\n" +
+	"\n" +
+	"amount_out\x18\x05 \x01(\tR\tamountOut\x12\x10\n" +
+	"\x03fee\x18\x06 \x01(\tR\x03fee\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"y\n" +
+	"\x17QuoterPriceIndexUpdated\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rexchange_rate\x18\x02 \x01(\tR\fexchangeRate\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"S\n" +
+	"\rQuoterUpdated\x12\x1d\n" +
+	"\n" +
+	"new_quoter\x18\x01 \x01(\tR\tnewQuoter\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"z\n" +
+	"\x0fSealStateChange\x12\x19\n" +
+	"\x05final\x18\x01 \x01(\bH\x00R\x05final\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"seal_state\x18\x02 \x01(\tR\tsealState\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReasonB\b\n" +
+	"\x06_final\"y\n" +
+	"\fTokenApprove\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x18\n" +
+	"\aspender\x18\x02 \x01(\tR\aspender\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"\xcb\x01\n" +
+	"\tTokenBurn\x12!\n" +
+	"\ftoken_burner\x18\x01 \x01(\tR\vtokenBurner\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x125\n" +
+	"\x05token\x18\r \x01(\v2\x1f.tracker.event.v1.TokenMetadataR\x05token\x12)\n" +
+	"\x10normalized_value\x18\x0e \x01(\tR\x0fnormalizedValue\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"\xdb\x01\n" +
+	"\tTokenMint\x12!\n" +
+	"\ftoken_minter\x18\x01 \x01(\tR\vtokenMinter\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x125\n" +
+	"\x05token\x18\r \x01(\v2\x1f.tracker.event.v1.TokenMetadataR\x05token\x12)\n" +
+	"\x10normalized_value\x18\x0e \x01(\tR\x0fnormalizedValue\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"\xea\x01\n" +
+	"\rTokenTransfer\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x18\n" +
+	"\aspender\x18\x03 \x01(\tR\aspender\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x125\n" +
+	"\x05token\x18\r \x01(\v2\x1f.tracker.event.v1.TokenMetadataR\x05token\x12)\n" +
+	"\x10normalized_value\x18\x0e \x01(\tR\x0fnormalizedValue\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"\xf4\x01\n" +
+	"\vTokenPermit\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x18\n" +
+	"\aspender\x18\x02 \x01(\tR\aspender\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x1a\n" +
+	"\bdeadline\x18\x04 \x01(\tR\bdeadline\x12\x14\n" +
+	"\x05nonce\x18\x05 \x01(\tR\x05nonce\x121\n" +
+	"\x12approval_log_index\x18\x06 \x01(\rH\x00R\x10approvalLogIndex\x88\x01\x01\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReasonB\x15\n" +
+	"\x13_approval_log_index\"q\n" +
+	"\vNFTTransfer\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x19\n" +
+	"\btoken_id\x18\x03 \x01(\tR\atokenId\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"\xae\x01\n" +
+	"\x12MultiTokenTransfer\x12\x1a\n" +
+	"\boperator\x18\x01 \x01(\tR\boperator\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x1b\n" +
+	"\ttoken_ids\x18\x04 \x03(\tR\btokenIds\x12\x16\n" +
+	"\x06values\x18\x05 \x03(\tR\x06values\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"\xcd\x01\n" +
+	"\rUserOperation\x12 \n" +
+	"\fuser_op_hash\x18\x01 \x01(\tR\n" +
+	"userOpHash\x12\x16\n" +
+	"\x06sender\x18\x02 \x01(\tR\x06sender\x12\x1c\n" +
+	"\tpaymaster\x18\x03 \x01(\tR\tpaymaster\x12\x14\n" +
+	"\x05nonce\x18\x04 \x01(\tR\x05nonce\x12&\n" +
+	"\x0factual_gas_cost\x18\x05 \x01(\tR\ractualGasCost\x12&\n" +
+	"\x0factual_gas_used\x18\x06 \x01(\tR\ractualGasUsed\"\x83\x01\n" +
+	"\x0fAccountDeployed\x12 \n" +
+	"\fuser_op_hash\x18\x01 \x01(\tR\n" +
+	"userOpHash\x12\x16\n" +
+	"\x06sender\x18\x02 \x01(\tR\x06sender\x12\x18\n" +
+	"\afactory\x18\x03 \x01(\tR\afactory\x12\x1c\n" +
+	"\tpaymaster\x18\x04 \x01(\tR\tpaymaster\"\x90\x01\n" +
+	"\x19UserOperationRevertReason\x12 \n" +
+	"\fuser_op_hash\x18\x01 \x01(\tR\n" +
+	"userOpHash\x12\x16\n" +
+	"\x06sender\x18\x02 \x01(\tR\x06sender\x12\x14\n" +
+	"\x05nonce\x18\x03 \x01(\tR\x05nonce\x12#\n" +
+	"\rrevert_reason\x18\x04 \x01(\tR\frevertReason\"1\n" +
+	"\x0eBlockProcessed\x12\x1f\n" +
+	"\vevent_count\x18\x01 \x01(\x04R\n" +
+	"eventCount\"~\n" +
+	"\n" +
+	"NFTApprove\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\tR\bapproved\x12\x19\n" +
+	"\btoken_id\x18\x03 \x01(\tR\atokenId\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReason\"\x83\x01\n" +
+	"\x0eApprovalForAll\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x1a\n" +
+	"\bapproved\x18\x03 \x01(\bR\bapproved\x12#\n" +
+	"\rrevert_reason\x18\x0f \x01(\tR\frevertReasonB>Z<github.com/grassrootseconomics/eth-tracker/pkg/event/eventpbb\x06proto3"
+
+var (
+	file_event_proto_rawDescOnce sync.Once
+	file_event_proto_rawDescData []byte
+)
+
+func file_event_proto_rawDescGZIP() []byte {
+	file_event_proto_rawDescOnce.Do(func() {
+		file_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)))
+	})
+	return file_event_proto_rawDescData
+}
+
+var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_event_proto_goTypes = []any{
+	(*Event)(nil),                     // 0: tracker.event.v1.Event
+	(*Tx)(nil),                        // 1: tracker.event.v1.Tx
+	(*TokenMetadata)(nil),             // 2: tracker.event.v1.TokenMetadata
+	(*ContractCreation)(nil),          // 3: tracker.event.v1.ContractCreation
+	(*CustodialRegistration)(nil),     // 4: tracker.event.v1.CustodialRegistration
+	(*FaucetGive)(nil),                // 5: tracker.event.v1.FaucetGive
+	(*IndexAdd)(nil),                  // 6: tracker.event.v1.IndexAdd
+	(*IndexRemove)(nil),               // 7: tracker.event.v1.IndexRemove
+	(*OwnershipTransferred)(nil),      // 8: tracker.event.v1.OwnershipTransferred
+	(*PoolDeposit)(nil),               // 9: tracker.event.v1.PoolDeposit
+	(*PoolSwap)(nil),                  // 10: tracker.event.v1.PoolSwap
+	(*QuoterPriceIndexUpdated)(nil),   // 11: tracker.event.v1.QuoterPriceIndexUpdated
+	(*QuoterUpdated)(nil),             // 12: tracker.event.v1.QuoterUpdated
+	(*SealStateChange)(nil),           // 13: tracker.event.v1.SealStateChange
+	(*TokenApprove)(nil),              // 14: tracker.event.v1.TokenApprove
+	(*TokenBurn)(nil),                 // 15: tracker.event.v1.TokenBurn
+	(*TokenMint)(nil),                 // 16: tracker.event.v1.TokenMint
+	(*TokenTransfer)(nil),             // 17: tracker.event.v1.TokenTransfer
+	(*TokenPermit)(nil),               // 18: tracker.event.v1.TokenPermit
+	(*NFTTransfer)(nil),               // 19: tracker.event.v1.NFTTransfer
+	(*MultiTokenTransfer)(nil),        // 20: tracker.event.v1.MultiTokenTransfer
+	(*UserOperation)(nil),             // 21: tracker.event.v1.UserOperation
+	(*AccountDeployed)(nil),           // 22: tracker.event.v1.AccountDeployed
+	(*UserOperationRevertReason)(nil), // 23: tracker.event.v1.UserOperationRevertReason
+	(*BlockProcessed)(nil),            // 24: tracker.event.v1.BlockProcessed
+	(*NFTApprove)(nil),                // 25: tracker.event.v1.NFTApprove
+	(*ApprovalForAll)(nil),            // 26: tracker.event.v1.ApprovalForAll
+}
+var file_event_proto_depIdxs = []int32{
+	1,  // 0: tracker.event.v1.Event.tx:type_name -> tracker.event.v1.Tx
+	3,  // 1: tracker.event.v1.Event.contract_creation:type_name -> tracker.event.v1.ContractCreation
+	4,  // 2: tracker.event.v1.Event.custodial_registration:type_name -> tracker.event.v1.CustodialRegistration
+	5,  // 3: tracker.event.v1.Event.faucet_give:type_name -> tracker.event.v1.FaucetGive
+	6,  // 4: tracker.event.v1.Event.index_add:type_name -> tracker.event.v1.IndexAdd
+	7,  // 5: tracker.event.v1.Event.index_remove:type_name -> tracker.event.v1.IndexRemove
+	8,  // 6: tracker.event.v1.Event.ownership_transferred:type_name -> tracker.event.v1.OwnershipTransferred
+	9,  // 7: tracker.event.v1.Event.pool_deposit:type_name -> tracker.event.v1.PoolDeposit
+	10, // 8: tracker.event.v1.Event.pool_swap:type_name -> tracker.event.v1.PoolSwap
+	11, // 9: tracker.event.v1.Event.quoter_price_index_updated:type_name -> tracker.event.v1.QuoterPriceIndexUpdated
+	12, // 10: tracker.event.v1.Event.quoter_updated:type_name -> tracker.event.v1.QuoterUpdated
+	13, // 11: tracker.event.v1.Event.seal_state_change:type_name -> tracker.event.v1.SealStateChange
+	14, // 12: tracker.event.v1.Event.token_approve:type_name -> tracker.event.v1.TokenApprove
+	15, // 13: tracker.event.v1.Event.token_burn:type_name -> tracker.event.v1.TokenBurn
+	16, // 14: tracker.event.v1.Event.token_mint:type_name -> tracker.event.v1.TokenMint
+	17, // 15: tracker.event.v1.Event.token_transfer:type_name -> tracker.event.v1.TokenTransfer
+	18, // 16: tracker.event.v1.Event.token_permit:type_name -> tracker.event.v1.TokenPermit
+	19, // 17: tracker.event.v1.Event.nft_transfer:type_name -> tracker.event.v1.NFTTransfer
+	20, // 18: tracker.event.v1.Event.multi_token_transfer:type_name -> tracker.event.v1.MultiTokenTransfer
+	21, // 19: tracker.event.v1.Event.user_operation:type_name -> tracker.event.v1.UserOperation
+	22, // 20: tracker.event.v1.Event.account_deployed:type_name -> tracker.event.v1.AccountDeployed
+	23, // 21: tracker.event.v1.Event.user_operation_revert_reason:type_name -> tracker.event.v1.UserOperationRevertReason
+	24, // 22: tracker.event.v1.Event.block_processed:type_name -> tracker.event.v1.BlockProcessed
+	25, // 23: tracker.event.v1.Event.nft_approve:type_name -> tracker.event.v1.NFTApprove
+	26, // 24: tracker.event.v1.Event.approval_for_all:type_name -> tracker.event.v1.ApprovalForAll
+	2,  // 25: tracker.event.v1.TokenBurn.token:type_name -> tracker.event.v1.TokenMetadata
+	2,  // 26: tracker.event.v1.TokenMint.token:type_name -> tracker.event.v1.TokenMetadata
+	2,  // 27: tracker.event.v1.TokenTransfer.token:type_name -> tracker.event.v1.TokenMetadata
+	28, // [28:28] is the sub-list for method output_type
+	28, // [28:28] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_event_proto_init() }
+func file_event_proto_init() {
+	if File_event_proto != nil {
+		return
+	}
+	file_event_proto_msgTypes[0].OneofWrappers = []any{
+		(*Event_ContractCreation)(nil),
+		(*Event_CustodialRegistration)(nil),
+		(*Event_FaucetGive)(nil),
+		(*Event_IndexAdd)(nil),
+		(*Event_IndexRemove)(nil),
+		(*Event_OwnershipTransferred)(nil),
+		(*Event_PoolDeposit)(nil),
+		(*Event_PoolSwap)(nil),
+		(*Event_QuoterPriceIndexUpdated)(nil),
+		(*Event_QuoterUpdated)(nil),
+		(*Event_SealStateChange)(nil),
+		(*Event_TokenApprove)(nil),
+		(*Event_TokenBurn)(nil),
+		(*Event_TokenMint)(nil),
+		(*Event_TokenTransfer)(nil),
+		(*Event_TokenPermit)(nil),
+		(*Event_NftTransfer)(nil),
+		(*Event_MultiTokenTransfer)(nil),
+		(*Event_UserOperation)(nil),
+		(*Event_AccountDeployed)(nil),
+		(*Event_UserOperationRevertReason)(nil),
+		(*Event_BlockProcessed)(nil),
+		(*Event_NftApprove)(nil),
+		(*Event_ApprovalForAll)(nil),
+	}
+	file_event_proto_msgTypes[13].OneofWrappers = []any{}
+	file_event_proto_msgTypes[18].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_event_proto_goTypes,
+		DependencyIndexes: file_event_proto_depIdxs,
+		MessageInfos:      file_event_proto_msgTypes,
+	}.Build()
+	File_event_proto = out.File
+	file_event_proto_goTypes = nil
+	file_event_proto_depIdxs = nil
+}
@@ -0,0 +1,4 @@
+// Package eventpb holds the generated Protobuf bindings of published events, use event.DeserializeAs to decode them into event.Event
+package eventpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative event.proto
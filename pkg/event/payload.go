@@ -0,0 +1,261 @@
+package event
+
+import "sort"
+
+type (
+	// Reverted is embedded in payloads of events that can be derived from reverted transactions
+	Reverted struct {
+		RevertReason string `json:"revertReason,omitempty"`
+	}
+
+	TokenMetadata struct {
+		Name     string `json:"name"`
+		Symbol   string `json:"symbol"`
+		Decimals uint8  `json:"decimals"`
+	}
+
+	// TokenValue is embedded in payloads that carry a raw token amount which can be enriched with token metadata
+	TokenValue struct {
+		Token           *TokenMetadata `json:"token,omitempty"`
+		NormalizedValue string         `json:"normalizedValue,omitempty"`
+	}
+
+	ContractCreationPayload struct {
+		From string `json:"from"`
+		Reverted
+	}
+
+	CustodialRegistrationPayload struct {
+		Account string `json:"account"`
+		Reverted
+	}
+
+	FaucetGivePayload struct {
+		Recipient string `json:"recipient"`
+		Token     string `json:"token"`
+		Amount    string `json:"amount"`
+		Reverted
+	}
+
+	IndexAddPayload struct {
+		Address string `json:"address"`
+		Reverted
+	}
+
+	IndexRemovePayload struct {
+		Address string `json:"address"`
+		Reverted
+	}
+
+	OwnershipTransferredPayload struct {
+		PreviousOwner string `json:"previousOwner"`
+		NewOwner      string `json:"newOwner"`
+		Reverted
+	}
+
+	PoolDepositPayload struct {
+		Initiator string `json:"initiator"`
+		TokenIn   string `json:"tokenIn"`
+		AmountIn  string `json:"amountIn"`
+		Reverted
+	}
+
+	PoolSwapPayload struct {
+		Initiator string `json:"initiator"`
+		TokenIn   string `json:"tokenIn"`
+		TokenOut  string `json:"tokenOut"`
+		AmountIn  string `json:"amountIn"`
+		AmountOut string `json:"amountOut"`
+		Fee       string `json:"fee"`
+		Reverted
+	}
+
+	QuoterPriceIndexUpdatedPayload struct {
+		Token        string `json:"token"`
+		ExchangeRate string `json:"exchangeRate"`
+		Reverted
+	}
+
+	QuoterUpdatedPayload struct {
+		NewQuoter string `json:"newQuoter"`
+		Reverted
+	}
+
+	SealStateChangePayload struct {
+		// Final is only known from the SealStateChange log
+		Final     *bool  `json:"final,omitempty"`
+		SealState string `json:"sealState"`
+		Reverted
+	}
+
+	TokenApprovePayload struct {
+		Owner   string `json:"owner"`
+		Spender string `json:"spender"`
+		Value   string `json:"value"`
+		Reverted
+	}
+
+	TokenBurnPayload struct {
+		TokenBurner string `json:"tokenBurner"`
+		Value       string `json:"value"`
+		TokenValue
+		Reverted
+	}
+
+	TokenMintPayload struct {
+		TokenMinter string `json:"tokenMinter"`
+		To          string `json:"to"`
+		Value       string `json:"value"`
+		TokenValue
+		Reverted
+	}
+
+	TokenTransferPayload struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		// Spender is only set for the TransferFrom log
+		Spender string `json:"spender,omitempty"`
+		Value   string `json:"value"`
+		TokenValue
+		Reverted
+	}
+
+	TokenPermitPayload struct {
+		Owner    string `json:"owner"`
+		Spender  string `json:"spender"`
+		Value    string `json:"value"`
+		Deadline string `json:"deadline"`
+		// Nonce is empty when the token does not expose nonces(address)
+		Nonce            string `json:"nonce"`
+		ApprovalLogIndex *uint  `json:"approvalLogIndex"`
+		Reverted
+	}
+
+	NFTTransferPayload struct {
+		From    string `json:"from"`
+		To      string `json:"to"`
+		TokenID string `json:"tokenId"`
+		Reverted
+	}
+
+	NFTApprovePayload struct {
+		Owner    string `json:"owner"`
+		Approved string `json:"approved"`
+		TokenID  string `json:"tokenId"`
+		Reverted
+	}
+
+	// ApprovalForAllPayload is shared by ERC-721 and ERC-1155 operator approvals
+	ApprovalForAllPayload struct {
+		Owner    string `json:"owner"`
+		Operator string `json:"operator"`
+		Approved bool   `json:"approved"`
+		Reverted
+	}
+
+	MultiTokenTransferPayload struct {
+		Operator string   `json:"operator"`
+		From     string   `json:"from"`
+		To       string   `json:"to"`
+		TokenIDs []string `json:"tokenIds"`
+		Values   []string `json:"values"`
+		Reverted
+	}
+
+	UserOperationPayload struct {
+		UserOpHash    string `json:"userOpHash"`
+		Sender        string `json:"sender"`
+		Paymaster     string `json:"paymaster"`
+		Nonce         string `json:"nonce"`
+		ActualGasCost string `json:"actualGasCost"`
+		ActualGasUsed string `json:"actualGasUsed"`
+	}
+
+	AccountDeployedPayload struct {
+		UserOpHash string `json:"userOpHash"`
+		Sender     string `json:"sender"`
+		Factory    string `json:"factory"`
+		Paymaster  string `json:"paymaster"`
+	}
+
+	UserOperationRevertReasonPayload struct {
+		UserOpHash   string `json:"userOpHash"`
+		Sender       string `json:"sender"`
+		Nonce        string `json:"nonce"`
+		RevertReason string `json:"revertReason"`
+	}
+
+	// BlockProcessedPayload closes the events of a block, the block number, hash and timestamp are part of the event
+	BlockProcessedPayload struct {
+		// EventCount is the number of events published for the block, excluding the marker
+		EventCount uint `json:"eventCount"`
+	}
+)
+
+var payloadTypes = map[string]func() any{
+	"CONTRACT_CREATION":            func() any { return &ContractCreationPayload{} },
+	"CUSTODIAL_REGISTRATION":       func() any { return &CustodialRegistrationPayload{} },
+	"FAUCET_GIVE":                  func() any { return &FaucetGivePayload{} },
+	"INDEX_ADD":                    func() any { return &IndexAddPayload{} },
+	"INDEX_REMOVE":                 func() any { return &IndexRemovePayload{} },
+	"OWNERSHIP_TRANSFERRED":        func() any { return &OwnershipTransferredPayload{} },
+	"POOL_DEPOSIT":                 func() any { return &PoolDepositPayload{} },
+	"POOL_SWAP":                    func() any { return &PoolSwapPayload{} },
+	"QUOTER_PRICE_INDEX_UPDATED":   func() any { return &QuoterPriceIndexUpdatedPayload{} },
+	"QUOTER_UPDATED":               func() any { return &QuoterUpdatedPayload{} },
+	"SEAL_STATE_CHANGE":            func() any { return &SealStateChangePayload{} },
+	"TOKEN_APPROVE":                func() any { return &TokenApprovePayload{} },
+	"TOKEN_BURN":                   func() any { return &TokenBurnPayload{} },
+	"TOKEN_MINT":                   func() any { return &TokenMintPayload{} },
+	"TOKEN_TRANSFER":               func() any { return &TokenTransferPayload{} },
+	"TOKEN_PERMIT":                 func() any { return &TokenPermitPayload{} },
+	"NFT_TRANSFER":                 func() any { return &NFTTransferPayload{} },
+	"NFT_APPROVE":                  func() any { return &NFTApprovePayload{} },
+	"APPROVAL_FOR_ALL":             func() any { return &ApprovalForAllPayload{} },
+	"MULTI_TOKEN_TRANSFER":         func() any { return &MultiTokenTransferPayload{} },
+	"USER_OPERATION":               func() any { return &UserOperationPayload{} },
+	"ACCOUNT_DEPLOYED":             func() any { return &AccountDeployedPayload{} },
+	"USER_OPERATION_REVERT_REASON": func() any { return &UserOperationRevertReasonPayload{} },
+	"BLOCK_PROCESSED":              func() any { return &BlockProcessedPayload{} },
+}
+
+// NewPayload returns a pointer to an empty payload for the TxType, or nil if the TxType is unknown
+func NewPayload(txType string) any {
+	newPayload, ok := payloadTypes[txType]
+	if !ok {
+		return nil
+	}
+
+	return newPayload()
+}
+
+func TxTypes() []string {
+	txTypes := make([]string, 0, len(payloadTypes))
+	for txType := range payloadTypes {
+		txTypes = append(txTypes, txType)
+	}
+	sort.Strings(txTypes)
+
+	return txTypes
+}
+
+func (r *Reverted) SetRevertReason(revertReason string) {
+	r.RevertReason = revertReason
+}
+
+func (t *TokenValue) SetTokenMetadata(metadata TokenMetadata, normalizedValue string) {
+	t.Token = &metadata
+	t.NormalizedValue = normalizedValue
+}
+
+func (p *TokenBurnPayload) RawValue() string {
+	return p.Value
+}
+
+func (p *TokenMintPayload) RawValue() string {
+	return p.Value
+}
+
+func (p *TokenTransferPayload) RawValue() string {
+	return p.Value
+}
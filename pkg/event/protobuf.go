@@ -0,0 +1,190 @@
+package event
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event/eventpb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Go fields are matched to Protobuf fields through their JSON names, which keeps both encodings in lockstep
+func (e Event) serializeProtobuf() ([]byte, error) {
+	msg := &eventpb.Event{}
+	m := msg.ProtoReflect()
+
+	if err := toProtoMessage(reflect.ValueOf(e), m); err != nil {
+		return nil, err
+	}
+
+	payloadField := payloadFieldDescriptor(m, e.TxType)
+	if payloadField == nil {
+		return nil, fmt.Errorf("transaction type %s has no protobuf payload", e.TxType)
+	}
+
+	payload := reflect.ValueOf(e.Payload)
+	if payload.Kind() != reflect.Pointer || payload.IsNil() || payload.Type() != reflect.TypeOf(NewPayload(e.TxType)) {
+		return nil, fmt.Errorf("unexpected payload type %T for transaction type %s", e.Payload, e.TxType)
+	}
+	if err := toProtoMessage(payload.Elem(), m.Mutable(payloadField).Message()); err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(msg)
+}
+
+func deserializeProtobuf(data []byte) (Event, error) {
+	var (
+		event Event
+		msg   eventpb.Event
+	)
+
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return event, err
+	}
+	m := msg.ProtoReflect()
+
+	if err := fromProtoMessage(m, reflect.ValueOf(&event).Elem()); err != nil {
+		return event, err
+	}
+
+	payloadField := payloadFieldDescriptor(m, event.TxType)
+	payload := NewPayload(event.TxType)
+	if payloadField == nil || payload == nil {
+		return event, fmt.Errorf("transaction type %s has no protobuf payload", event.TxType)
+	}
+	if err := fromProtoMessage(m.Get(payloadField).Message(), reflect.ValueOf(payload).Elem()); err != nil {
+		return event, err
+	}
+	event.Payload = payload
+
+	return event, nil
+}
+
+// payloadFieldDescriptor resolves the payload oneof field, e.g. TOKEN_TRANSFER is stored in token_transfer
+func payloadFieldDescriptor(m protoreflect.Message, txType string) protoreflect.FieldDescriptor {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(strings.ToLower(txType)))
+	if fd == nil || fd.ContainingOneof() == nil {
+		return nil
+	}
+
+	return fd
+}
+
+func toProtoMessage(v reflect.Value, m protoreflect.Message) error {
+	return walkFields(v, m.Descriptor(), func(field reflect.Value, fd protoreflect.FieldDescriptor) error {
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				return nil
+			}
+			field = field.Elem()
+		}
+
+		switch field.Kind() {
+		case reflect.Struct:
+			return toProtoMessage(field, m.Mutable(fd).Message())
+		case reflect.Slice:
+			list := m.Mutable(fd).List()
+			for i := range field.Len() {
+				list.Append(protoreflect.ValueOfString(field.Index(i).String()))
+			}
+		default:
+			value, err := toProtoValue(field, fd)
+			if err != nil {
+				return err
+			}
+			m.Set(fd, value)
+		}
+
+		return nil
+	})
+}
+
+func fromProtoMessage(m protoreflect.Message, v reflect.Value) error {
+	return walkFields(v, m.Descriptor(), func(field reflect.Value, fd protoreflect.FieldDescriptor) error {
+		if fd.HasPresence() && !m.Has(fd) {
+			return nil
+		}
+
+		if field.Kind() == reflect.Pointer {
+			field.Set(reflect.New(field.Type().Elem()))
+			field = field.Elem()
+		}
+
+		switch field.Kind() {
+		case reflect.Struct:
+			return fromProtoMessage(m.Get(fd).Message(), field)
+		case reflect.Slice:
+			list := m.Get(fd).List()
+			values := reflect.MakeSlice(field.Type(), list.Len(), list.Len())
+			for i := range list.Len() {
+				values.Index(i).SetString(list.Get(i).String())
+			}
+			field.Set(values)
+		case reflect.String:
+			field.SetString(m.Get(fd).String())
+		case reflect.Bool:
+			field.SetBool(m.Get(fd).Bool())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			field.SetUint(m.Get(fd).Uint())
+		default:
+			return fmt.Errorf("unsupported field type %s", field.Type())
+		}
+
+		return nil
+	})
+}
+
+func toProtoValue(field reflect.Value, fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case reflect.String:
+		return protoreflect.ValueOfString(field.String()), nil
+	case reflect.Bool:
+		return protoreflect.ValueOfBool(field.Bool()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if fd.Kind() == protoreflect.Uint32Kind {
+			return protoreflect.ValueOfUint32(uint32(field.Uint())), nil
+		}
+		return protoreflect.ValueOfUint64(field.Uint()), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported field type %s", field.Type())
+	}
+}
+
+// walkFields visits every JSON encoded field of the struct, embedded structs are flattened and the event payload is skipped
+func walkFields(v reflect.Value, md protoreflect.MessageDescriptor, fn func(reflect.Value, protoreflect.FieldDescriptor) error) error {
+	t := v.Type()
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if field.Anonymous && tag == "" {
+			if err := walkFields(v.Field(i), md, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" || name == "payload" {
+			continue
+		}
+
+		fd := md.Fields().ByJSONName(name)
+		if fd == nil {
+			return fmt.Errorf("%s has no protobuf field for %s", md.FullName(), name)
+		}
+
+		if err := fn(v.Field(i), fd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
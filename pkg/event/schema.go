@@ -0,0 +1,108 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//go:generate go run ../../cmd/schemagen -out schema
+
+const (
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	schemaIDPrefix  = "https://github.com/grassrootseconomics/eth-tracker/pkg/event/schema/"
+)
+
+// JSONSchema returns the JSON Schema document describing a serialized event of the TxType
+func JSONSchema(txType string) ([]byte, error) {
+	payload := NewPayload(txType)
+	if payload == nil {
+		return nil, fmt.Errorf("unknown transaction type %s", txType)
+	}
+
+	envelope := objectSchema(reflect.TypeOf(Event{}))
+	properties := envelope["properties"].(map[string]any)
+	properties["schemaVersion"] = map[string]any{"const": SchemaVersion}
+	properties["transactionType"] = map[string]any{"const": txType}
+	properties["payload"] = objectSchema(reflect.TypeOf(payload).Elem())
+
+	envelope["$schema"] = jsonSchemaDraft
+	envelope["$id"] = schemaIDPrefix + txType + ".json"
+	envelope["title"] = txType
+
+	return json.MarshalIndent(envelope, "", "  ")
+}
+
+func objectSchema(t reflect.Type) map[string]any {
+	var (
+		properties = map[string]any{}
+		required   = []string{}
+	)
+
+	collectProperties(t, properties, &required)
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// collectProperties follows encoding/json semantics, embedded structs without a tag are flattened into the parent
+func collectProperties(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if field.Anonymous && tag == "" {
+			collectProperties(field.Type, properties, required)
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		omitEmpty := strings.Contains(opts, "omitempty")
+		properties[name] = typeSchema(field.Type, !omitEmpty)
+		if !omitEmpty {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func typeSchema(t reflect.Type, nullable bool) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		schema := typeSchema(t.Elem(), false)
+		if nullable {
+			return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+		}
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), false)}
+	case reflect.Struct:
+		return objectSchema(t)
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,22 @@
+package router
+
+import "errors"
+
+// DecodeError marks a failure that processing the block again cannot fix, handlers wrap their ABI decoding errors in it
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// IsDecodeError reports whether the error comes from decoding chain data rather than from fetching or publishing it
+func IsDecodeError(err error) bool {
+	var decodeErr *DecodeError
+	return errors.As(err, &decodeErr)
+}
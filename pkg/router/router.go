@@ -14,24 +14,40 @@ type (
 	LogPayload struct {
 		Log       *types.Log
 		Timestamp uint64
+		// Sender is the smart account for logs emitted while executing an ERC-4337 UserOperation
+		Sender string
+		Tx     *event.Tx
 	}
 
 	InputDataPayload struct {
 		From            string
 		InputData       string
 		Block           uint64
+		BlockHash       string
 		ContractAddress string
 		Timestamp       uint64
 		TxHash          string
+		TxIndex         uint
+		Success         bool
+		RevertReason    string
+		// CallIndex is the position of an unwrapped inner call, 0 for the outer call
+		CallIndex uint
+		// Logs are the receipt logs, only set for successful transactions
+		Logs []*types.Log
+		Tx   *event.Tx
 	}
 
 	ContractCreationPayload struct {
 		From            string
 		ContractAddress string
 		Block           uint64
+		BlockHash       string
 		Timestamp       uint64
 		TxHash          string
+		TxIndex         uint
 		Success         bool
+		RevertReason    string
+		Tx              *event.Tx
 	}
 
 	LogHandlerFunc              func(context.Context, LogPayload, Callback) error
@@ -49,19 +65,21 @@ type (
 	}
 
 	Router struct {
-		callbackFn              Callback
-		logHandlers             map[common.Hash]LogRouteEntry
-		inputDataHandlers       map[string]InputDataEntry
-		contractCreationHandler ContractCreationHandlerFunc
+		callbackFn               Callback
+		logHandlers              map[common.Hash]LogRouteEntry
+		inputDataHandlers        map[string]InputDataEntry
+		successInputDataHandlers map[string]InputDataEntry
+		contractCreationHandler  ContractCreationHandlerFunc
 	}
 )
 
 func New(callbackFn Callback) *Router {
 	return &Router{
-		callbackFn:              callbackFn,
-		logHandlers:             make(map[common.Hash]LogRouteEntry),
-		inputDataHandlers:       make(map[string]InputDataEntry),
-		contractCreationHandler: nil,
+		callbackFn:               callbackFn,
+		logHandlers:              make(map[common.Hash]LogRouteEntry),
+		inputDataHandlers:        make(map[string]InputDataEntry),
+		successInputDataHandlers: make(map[string]InputDataEntry),
+		contractCreationHandler:  nil,
 	}
 }
 
@@ -79,6 +97,14 @@ func (r *Router) RegisterInputDataRoute(signature string, handlerFunc InputDataH
 	}
 }
 
+// RegisterSuccessInputDataRoute registers a handler for input data of successful transactions
+func (r *Router) RegisterSuccessInputDataRoute(signature string, handlerFunc InputDataHandlerFunc) {
+	r.successInputDataHandlers[signature] = InputDataEntry{
+		Signature:   signature,
+		HandlerFunc: handlerFunc,
+	}
+}
+
 func (r *Router) RegisterContractCreationHandler(handlerFunc ContractCreationHandlerFunc) {
 	r.contractCreationHandler = handlerFunc
 }
@@ -86,7 +112,7 @@ func (r *Router) RegisterContractCreationHandler(handlerFunc ContractCreationHan
 func (r *Router) ProcessLog(ctx context.Context, payload LogPayload) error {
 	handler, ok := r.logHandlers[payload.Log.Topics[0]]
 	if ok {
-		return handler.HandlerFunc(ctx, payload, r.callbackFn)
+		return handler.HandlerFunc(ctx, payload, withUserOpSender(withPosition(withTx(r.callbackFn, payload.Tx), payload.Log.BlockHash.Hex(), payload.Log.TxIndex), payload.Sender))
 	}
 
 	return nil
@@ -99,12 +125,89 @@ func (r *Router) ProcessInputData(ctx context.Context, payload InputDataPayload)
 
 	handler, ok := r.inputDataHandlers[payload.InputData[:8]]
 	if ok {
-		return handler.HandlerFunc(ctx, payload, r.callbackFn)
+		return handler.HandlerFunc(ctx, payload, withInputData(r.callbackFn, payload))
+	}
+
+	return nil
+}
+
+func (r *Router) HasSuccessInputDataRoute(inputData []byte) bool {
+	if len(inputData) < 4 {
+		return false
+	}
+
+	_, ok := r.successInputDataHandlers[common.Bytes2Hex(inputData[:4])]
+	return ok
+}
+
+func (r *Router) ProcessSuccessInputData(ctx context.Context, payload InputDataPayload) error {
+	if len(payload.InputData) < 8 {
+		return nil
+	}
+
+	handler, ok := r.successInputDataHandlers[payload.InputData[:8]]
+	if ok {
+		return handler.HandlerFunc(ctx, payload, withCallIndex(withPosition(withTx(r.callbackFn, payload.Tx), payload.BlockHash, payload.TxIndex), payload.CallIndex))
 	}
 
 	return nil
 }
 
 func (r *Router) ProcessContractCreation(ctx context.Context, payload ContractCreationPayload) error {
-	return r.contractCreationHandler(ctx, payload, r.callbackFn)
+	return r.contractCreationHandler(
+		ctx,
+		payload,
+		withRevertReason(withPosition(withTx(r.callbackFn, payload.Tx), payload.BlockHash, payload.TxIndex), payload.RevertReason),
+	)
+}
+
+func withInputData(callbackFn Callback, payload InputDataPayload) Callback {
+	return withCallIndex(withRevertReason(withPosition(withTx(callbackFn, payload.Tx), payload.BlockHash, payload.TxIndex), payload.RevertReason), payload.CallIndex)
+}
+
+// withUserOpSender attributes every event derived from a log emitted by a UserOperation to its smart account
+func withUserOpSender(callbackFn Callback, sender string) Callback {
+	return func(ctx context.Context, e event.Event) error {
+		e.UserOpSender = sender
+
+		return callbackFn(ctx, e)
+	}
+}
+
+// withCallIndex marks events derived from input data, which have no log of their own
+func withCallIndex(callbackFn Callback, callIndex uint) Callback {
+	return func(ctx context.Context, e event.Event) error {
+		e.CallIndex = &callIndex
+
+		return callbackFn(ctx, e)
+	}
+}
+
+func withRevertReason(callbackFn Callback, revertReason string) Callback {
+	return func(ctx context.Context, e event.Event) error {
+		if payload, ok := e.Payload.(event.RevertReasonSetter); ok && !e.Success {
+			payload.SetRevertReason(revertReason)
+		}
+
+		return callbackFn(ctx, e)
+	}
+}
+
+func withTx(callbackFn Callback, tx *event.Tx) Callback {
+	return func(ctx context.Context, e event.Event) error {
+		if e.Tx == nil {
+			e.Tx = tx
+		}
+
+		return callbackFn(ctx, e)
+	}
+}
+
+func withPosition(callbackFn Callback, blockHash string, txIndex uint) Callback {
+	return func(ctx context.Context, e event.Event) error {
+		e.BlockHash = blockHash
+		e.TxIndex = txIndex
+
+		return callbackFn(ctx, e)
+	}
 }
@@ -0,0 +1,37 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessLog_AttributesUserOpSender(t *testing.T) {
+	var published []event.Event
+
+	router := New(func(_ context.Context, ev event.Event) error {
+		published = append(published, ev)
+		return nil
+	})
+
+	topic := common.HexToHash("0x01")
+	router.RegisterLogRoute(topic, func(ctx context.Context, lp LogPayload, c Callback) error {
+		// Handlers that do not filter on the sender still publish attributed events
+		return c(ctx, event.Event{Index: lp.Log.Index, TxType: "TOKEN_MINT"})
+	})
+
+	for _, sender := range []string{"0x000000000000000000000000000000000000a11c", ""} {
+		require.NoError(t, router.ProcessLog(context.Background(), LogPayload{
+			Log:    &types.Log{Topics: []common.Hash{topic}},
+			Sender: sender,
+		}))
+	}
+
+	require.Len(t, published, 2)
+	require.Equal(t, "0x000000000000000000000000000000000000a11c", published[0].UserOpSender)
+	require.Empty(t, published[1].UserOpSender)
+}
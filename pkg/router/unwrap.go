@@ -0,0 +1,260 @@
+package router
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+)
+
+type (
+	unwrapFunc func(InputDataPayload, []byte) []InputDataPayload
+
+	multicall3Call struct {
+		Target   common.Address
+		CallData []byte
+	}
+
+	multicall3Call3 struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	}
+
+	multicall3Call3Value struct {
+		Target       common.Address
+		AllowFailure bool
+		Value        *big.Int
+		CallData     []byte
+	}
+)
+
+const (
+	maxUnwrapDepth = 4
+
+	safeOperationCall         = 0
+	safeOperationDelegateCall = 1
+)
+
+var (
+	multicallSig                 = w3.MustNewFunc("multicall(bytes[] data)", "bytes[]")
+	multicallWithDeadlineSig     = w3.MustNewFunc("multicall(uint256 deadline, bytes[] data)", "bytes[]")
+	multicall3AggregateSig       = w3.MustNewFunc("aggregate((address target, bytes callData)[] calls)", "")
+	multicall3TryAggregateSig    = w3.MustNewFunc("tryAggregate(bool requireSuccess, (address target, bytes callData)[] calls)", "")
+	multicall3Aggregate3Sig      = w3.MustNewFunc("aggregate3((address target, bool allowFailure, bytes callData)[] calls)", "")
+	multicall3Aggregate3ValueSig = w3.MustNewFunc("aggregate3Value((address target, bool allowFailure, uint256 value, bytes callData)[] calls)", "")
+	safeExecTransactionSig       = w3.MustNewFunc("execTransaction(address to, uint256 value, bytes data, uint8 operation, uint256 safeTxGas, uint256 baseGas, uint256 gasPrice, address gasToken, address refundReceiver, bytes signatures)", "bool")
+	safeMultiSendSig             = w3.MustNewFunc("multiSend(bytes transactions)", "")
+
+	unwrapFuncs = map[[4]byte]unwrapFunc{
+		multicallSig.Selector:                 unwrapMulticall,
+		multicallWithDeadlineSig.Selector:     unwrapMulticallWithDeadline,
+		multicall3AggregateSig.Selector:       unwrapMulticall3Aggregate,
+		multicall3TryAggregateSig.Selector:    unwrapMulticall3TryAggregate,
+		multicall3Aggregate3Sig.Selector:      unwrapMulticall3Aggregate3,
+		multicall3Aggregate3ValueSig.Selector: unwrapMulticall3Aggregate3Value,
+		safeExecTransactionSig.Selector:       unwrapSafeExecTransaction,
+		safeMultiSendSig.Selector:             unwrapSafeMultiSend,
+	}
+)
+
+// UnwrapInputData returns the payload followed by the inner calls of known batching and proxy call formats.
+// Inner calls carry the address they were executed against and the address that called them.
+func UnwrapInputData(payload InputDataPayload) []InputDataPayload {
+	payloads := []InputDataPayload{payload}
+	unwrapInputData(payload, 0, &payloads)
+
+	return payloads
+}
+
+func unwrapInputData(payload InputDataPayload, depth int, payloads *[]InputDataPayload) {
+	if depth >= maxUnwrapDepth || len(payload.InputData) < 8 {
+		return
+	}
+
+	inputData := w3.B(payload.InputData)
+	if len(inputData) < 4 {
+		return
+	}
+
+	unwrap, ok := unwrapFuncs[[4]byte(inputData[:4])]
+	if !ok {
+		return
+	}
+
+	for _, inner := range unwrap(payload, inputData) {
+		inner.CallIndex = uint(len(*payloads))
+		*payloads = append(*payloads, inner)
+		unwrapInputData(inner, depth+1, payloads)
+	}
+}
+
+func innerCall(outer InputDataPayload, from string, contractAddress string, inputData []byte) InputDataPayload {
+	outer.From = from
+	outer.ContractAddress = contractAddress
+	outer.InputData = common.Bytes2Hex(inputData)
+
+	return outer
+}
+
+// Self multicalls delegatecall into the same contract, so the sender is preserved
+func unwrapMulticall(outer InputDataPayload, inputData []byte) []InputDataPayload {
+	var data [][]byte
+	if err := multicallSig.DecodeArgs(inputData, &data); err != nil {
+		return nil
+	}
+
+	return selfCalls(outer, data)
+}
+
+func unwrapMulticallWithDeadline(outer InputDataPayload, inputData []byte) []InputDataPayload {
+	var (
+		deadline big.Int
+		data     [][]byte
+	)
+	if err := multicallWithDeadlineSig.DecodeArgs(inputData, &deadline, &data); err != nil {
+		return nil
+	}
+
+	return selfCalls(outer, data)
+}
+
+func selfCalls(outer InputDataPayload, data [][]byte) []InputDataPayload {
+	inner := make([]InputDataPayload, len(data))
+	for i, callData := range data {
+		inner[i] = innerCall(outer, outer.From, outer.ContractAddress, callData)
+	}
+
+	return inner
+}
+
+// Multicall3 calls each target itself, so the target sees the Multicall3 contract as the sender
+func unwrapMulticall3Aggregate(outer InputDataPayload, inputData []byte) []InputDataPayload {
+	var calls []multicall3Call
+	if err := multicall3AggregateSig.DecodeArgs(inputData, &calls); err != nil {
+		return nil
+	}
+
+	return multicall3Calls(outer, calls)
+}
+
+func unwrapMulticall3TryAggregate(outer InputDataPayload, inputData []byte) []InputDataPayload {
+	var (
+		requireSuccess bool
+		calls          []multicall3Call
+	)
+	if err := multicall3TryAggregateSig.DecodeArgs(inputData, &requireSuccess, &calls); err != nil {
+		return nil
+	}
+
+	return multicall3Calls(outer, calls)
+}
+
+func unwrapMulticall3Aggregate3(outer InputDataPayload, inputData []byte) []InputDataPayload {
+	var calls []multicall3Call3
+	if err := multicall3Aggregate3Sig.DecodeArgs(inputData, &calls); err != nil {
+		return nil
+	}
+
+	inner := make([]InputDataPayload, len(calls))
+	for i, call := range calls {
+		inner[i] = innerCall(outer, outer.ContractAddress, call.Target.Hex(), call.CallData)
+	}
+
+	return inner
+}
+
+func unwrapMulticall3Aggregate3Value(outer InputDataPayload, inputData []byte) []InputDataPayload {
+	var calls []multicall3Call3Value
+	if err := multicall3Aggregate3ValueSig.DecodeArgs(inputData, &calls); err != nil {
+		return nil
+	}
+
+	inner := make([]InputDataPayload, len(calls))
+	for i, call := range calls {
+		inner[i] = innerCall(outer, outer.ContractAddress, call.Target.Hex(), call.CallData)
+	}
+
+	return inner
+}
+
+func multicall3Calls(outer InputDataPayload, calls []multicall3Call) []InputDataPayload {
+	inner := make([]InputDataPayload, len(calls))
+	for i, call := range calls {
+		inner[i] = innerCall(outer, outer.ContractAddress, call.Target.Hex(), call.CallData)
+	}
+
+	return inner
+}
+
+// A Safe calls the target itself, delegatecalls are only followed into MultiSend which executes in the Safe's context
+func unwrapSafeExecTransaction(outer InputDataPayload, inputData []byte) []InputDataPayload {
+	var (
+		to             common.Address
+		value          big.Int
+		data           []byte
+		operation      uint8
+		safeTxGas      big.Int
+		baseGas        big.Int
+		gasPrice       big.Int
+		gasToken       common.Address
+		refundReceiver common.Address
+		signatures     []byte
+	)
+	if err := safeExecTransactionSig.DecodeArgs(
+		inputData,
+		&to,
+		&value,
+		&data,
+		&operation,
+		&safeTxGas,
+		&baseGas,
+		&gasPrice,
+		&gasToken,
+		&refundReceiver,
+		&signatures,
+	); err != nil {
+		return nil
+	}
+
+	switch operation {
+	case safeOperationCall:
+		return []InputDataPayload{innerCall(outer, outer.ContractAddress, to.Hex(), data)}
+	case safeOperationDelegateCall:
+		if len(data) >= 4 && [4]byte(data[:4]) == safeMultiSendSig.Selector {
+			return unwrapSafeMultiSend(outer, data)
+		}
+	}
+
+	return nil
+}
+
+// multiSend packs each transaction as operation (1 byte), to (20 bytes), value (32 bytes), data length (32 bytes), data
+func unwrapSafeMultiSend(outer InputDataPayload, inputData []byte) []InputDataPayload {
+	var transactions []byte
+	if err := safeMultiSendSig.DecodeArgs(inputData, &transactions); err != nil {
+		return nil
+	}
+
+	var inner []InputDataPayload
+	for i := 0; i+85 <= len(transactions); {
+		var (
+			operation = transactions[i]
+			to        = common.BytesToAddress(transactions[i+1 : i+21])
+			dataLen   = new(big.Int).SetBytes(transactions[i+53 : i+85])
+		)
+		i += 85
+
+		if !dataLen.IsUint64() || dataLen.Uint64() > uint64(len(transactions)-i) {
+			break
+		}
+		data := transactions[i : i+int(dataLen.Uint64())]
+		i += int(dataLen.Uint64())
+
+		if operation == safeOperationCall {
+			inner = append(inner, innerCall(outer, outer.ContractAddress, to.Hex(), data))
+		}
+	}
+
+	return inner
+}
@@ -0,0 +1,174 @@
+package router
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/grassrootseconomics/eth-tracker/pkg/event"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAlice      = "0x000000000000000000000000000000000000a11c"
+	testCUSD       = "0x765DE816845861e75A25fCA122bb6898B8B1282a"
+	testUSDC       = "0xcebA9300f2b948710d2653dD7B07f33A8B32118C"
+	testMulticall3 = "0xcA11bde05977b3631167028862bE2a173976CA11"
+	testSafe       = "0x00000000000000000000000000000000000005Af"
+	testMultiSend  = "0x40A2aCCbd92BCA938b02010E17A5b8929b49130D"
+
+	// transfer(0x...0b0b, 1000000) and transfer(0x...0b0b, 2000000)
+	testTransfer1 = "a9059cbb0000000000000000000000000000000000000000000000000000000000000b0b00000000000000000000000000000000000000000000000000000000000f4240"
+	testTransfer2 = "a9059cbb0000000000000000000000000000000000000000000000000000000000000b0b00000000000000000000000000000000000000000000000000000000001e8480"
+)
+
+type unwrappedCall struct {
+	From            string
+	ContractAddress string
+	InputData       string
+	CallIndex       uint
+}
+
+func TestUnwrapInputData(t *testing.T) {
+	calldata := loadCalldata(t)
+
+	tests := []struct {
+		name     string
+		fixture  string
+		contract string
+		want     []unwrappedCall
+	}{
+		{"Multicall", "multicall", testCUSD, []unwrappedCall{
+			{testAlice, testCUSD, testTransfer1, 1},
+			{testAlice, testCUSD, testTransfer2, 2},
+		}},
+		{"MulticallWithDeadline", "multicallDeadline", testCUSD, []unwrappedCall{
+			{testAlice, testCUSD, testTransfer1, 1},
+		}},
+		{"Multicall3Aggregate", "aggregate", testMulticall3, []unwrappedCall{
+			{testMulticall3, testCUSD, testTransfer1, 1},
+			{testMulticall3, testUSDC, testTransfer2, 2},
+		}},
+		{"Multicall3TryAggregate", "tryAggregate", testMulticall3, []unwrappedCall{
+			{testMulticall3, testCUSD, testTransfer1, 1},
+		}},
+		{"Multicall3Aggregate3", "aggregate3", testMulticall3, []unwrappedCall{
+			{testMulticall3, testCUSD, testTransfer1, 1},
+			{testMulticall3, testUSDC, testTransfer2, 2},
+		}},
+		{"Multicall3Aggregate3Value", "aggregate3Value", testMulticall3, []unwrappedCall{
+			{testMulticall3, testCUSD, testTransfer1, 1},
+		}},
+		{"SafeExecTransaction", "execTransaction", testSafe, []unwrappedCall{
+			{testSafe, testCUSD, testTransfer1, 1},
+		}},
+		// The delegatecall entry of the MultiSend batch is skipped
+		{"SafeExecTransactionMultiSend", "execTransactionMultiSend", testSafe, []unwrappedCall{
+			{testSafe, testCUSD, testTransfer1, 1},
+			{testSafe, testUSDC, testTransfer2, 2},
+		}},
+		{"MultiSend", "multiSend", testMultiSend, []unwrappedCall{
+			{testMultiSend, testCUSD, testTransfer1, 1},
+			{testMultiSend, testUSDC, testTransfer2, 2},
+		}},
+		// The second transaction claims more data than the batch holds
+		{"MultiSendTruncated", "multiSendTruncated", testMultiSend, []unwrappedCall{
+			{testMultiSend, testCUSD, testTransfer1, 1},
+		}},
+		// aggregate3 into a self multicall of the target
+		{"Nested", "nested", testMulticall3, []unwrappedCall{
+			{testMulticall3, testCUSD, nestedMulticall(calldata["nested"]), 1},
+			{testMulticall3, testCUSD, testTransfer1, 2},
+		}},
+		{"NotWrapped", "", testCUSD, nil},
+		{"MalformedMulticall", "", testCUSD, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inputData, ok := calldata[tc.fixture]
+			switch tc.name {
+			case "NotWrapped":
+				inputData, ok = testTransfer1, true
+			case "MalformedMulticall":
+				inputData, ok = calldata["multicall"][:200], true
+			}
+			require.True(t, ok, "missing fixture %s", tc.fixture)
+
+			payloads := UnwrapInputData(InputDataPayload{
+				From:            testAlice,
+				ContractAddress: tc.contract,
+				InputData:       inputData,
+			})
+			require.Equal(t, inputData, payloads[0].InputData)
+
+			var got []unwrappedCall
+			for _, payload := range payloads[1:] {
+				got = append(got, unwrappedCall{payload.From, payload.ContractAddress, payload.InputData, payload.CallIndex})
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestProcessInputData_SetsCallIndex(t *testing.T) {
+	var published []event.Event
+
+	router := New(func(_ context.Context, ev event.Event) error {
+		published = append(published, ev)
+		return nil
+	})
+	router.RegisterInputDataRoute("a9059cbb", func(ctx context.Context, idp InputDataPayload, c Callback) error {
+		return c(ctx, event.Event{TxHash: idp.TxHash, TxType: "TOKEN_TRANSFER", Payload: &event.TokenTransferPayload{}})
+	})
+
+	payloads := UnwrapInputData(InputDataPayload{
+		From:            testAlice,
+		ContractAddress: testCUSD,
+		InputData:       loadCalldata(t)["multicall"],
+		TxHash:          "0x01",
+	})
+	for _, payload := range payloads {
+		require.NoError(t, router.ProcessInputData(context.Background(), payload))
+	}
+
+	require.Len(t, published, 2)
+	for i, ev := range published {
+		require.Zero(t, ev.Index)
+		require.Equal(t, uint(i+1), *ev.CallIndex)
+	}
+	require.Equal(t, "0x01:call:1", published[0].ID())
+	require.Equal(t, "0x01:call:2", published[1].ID())
+}
+
+// nestedMulticall extracts the multicall([transfer1]) call data embedded in the nested fixture
+func nestedMulticall(nested string) string {
+	start := strings.Index(nested, "ac9650d8")
+	return nested[start : start+456]
+}
+
+// loadCalldata reads the ABI encoded fixtures, see testdata/unwrap_calldata.txt
+func loadCalldata(t *testing.T) map[string]string {
+	f, err := os.Open("testdata/unwrap_calldata.txt")
+	require.NoError(t, err)
+	defer f.Close()
+
+	calldata := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, hex, ok := strings.Cut(line, " ")
+		require.True(t, ok, line)
+		calldata[name] = hex
+	}
+	require.NoError(t, scanner.Err())
+
+	return calldata
+}
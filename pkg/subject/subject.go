@@ -0,0 +1,105 @@
+// Package subject builds the NATS subjects tracker events are published on from a subject template
+package subject
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// Template is a dot separated subject where whole tokens can be placeholders, e.g. {stream}.{chainId}.{eventType}.{contract}
+	Template struct {
+		tokens []string
+	}
+
+	Values struct {
+		Stream    string
+		ChainID   string
+		EventType string
+		// Contract is lowercased so that subjects do not depend on the address checksum
+		Contract string
+	}
+)
+
+const (
+	Stream    = "{stream}"
+	ChainID   = "{chainId}"
+	EventType = "{eventType}"
+	Contract  = "{contract}"
+
+	DefaultTemplate = Stream + "." + EventType
+
+	wildcard = "*"
+)
+
+var placeholders = map[string]struct{}{
+	Stream:    {},
+	ChainID:   {},
+	EventType: {},
+	Contract:  {},
+}
+
+func Parse(template string) (Template, error) {
+	if template == "" {
+		template = DefaultTemplate
+	}
+
+	tokens := strings.Split(template, ".")
+	for _, token := range tokens {
+		if token == "" {
+			return Template{}, fmt.Errorf("subject template %s has an empty token", template)
+		}
+		if strings.ContainsAny(token, "*> \t") {
+			return Template{}, fmt.Errorf("subject template %s contains wildcards or whitespace", template)
+		}
+		if strings.ContainsAny(token, "{}") {
+			if _, ok := placeholders[token]; !ok {
+				return Template{}, fmt.Errorf("subject template %s: placeholder %s must be one of {stream}, {chainId}, {eventType} or {contract} and fill a whole token", template, token)
+			}
+		}
+	}
+
+	return Template{tokens: tokens}, nil
+}
+
+// Has reports whether the placeholder is part of the template
+func (t Template) Has(placeholder string) bool {
+	for _, token := range t.tokens {
+		if token == placeholder {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Format replaces the placeholders with the values, placeholders without a value become a * wildcard
+func (t Template) Format(v Values) string {
+	tokens := make([]string, len(t.tokens))
+	for i, token := range t.tokens {
+		var value string
+		switch token {
+		case Stream:
+			value = v.Stream
+		case ChainID:
+			value = v.ChainID
+		case EventType:
+			value = v.EventType
+		case Contract:
+			value = strings.ToLower(v.Contract)
+		default:
+			value = token
+		}
+
+		if value == "" {
+			value = wildcard
+		}
+		tokens[i] = value
+	}
+
+	return strings.Join(tokens, ".")
+}
+
+func (t Template) String() string {
+	return strings.Join(t.tokens, ".")
+}
@@ -0,0 +1,38 @@
+package subject
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplate_Format(t *testing.T) {
+	template, err := Parse("TRACKER.{chainId}.{eventType}.{contract}")
+	require.NoError(t, err)
+
+	require.True(t, template.Has(Contract))
+	require.False(t, template.Has(Stream))
+	require.Equal(t, "TRACKER.42220.TOKEN_TRANSFER.0xabc", template.Format(Values{
+		ChainID:   "42220",
+		EventType: "TOKEN_TRANSFER",
+		Contract:  "0xAbC",
+	}))
+	require.Equal(t, "TRACKER.*.TOKEN_TRANSFER.*", template.Format(Values{EventType: "TOKEN_TRANSFER"}))
+
+	defaultTemplate, err := Parse("")
+	require.NoError(t, err)
+	require.Equal(t, "TRACKER.*", defaultTemplate.Format(Values{Stream: "TRACKER"}))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, template := range []string{
+		"TRACKER..{eventType}",
+		"TRACKER.>",
+		"TRACKER.*.{eventType}",
+		"TRACKER.{event}",
+		"TRACKER.type-{eventType}",
+	} {
+		_, err := Parse(template)
+		require.Error(t, err, template)
+	}
+}